@@ -0,0 +1,47 @@
+// Command readme regenerates the Inputs and Outputs tables of every module README
+// from the module's variables.tf and outputs.tf.
+//
+//	go run ./cmd/readme          # rewrite stale documents
+//	go run ./cmd/readme --check  # fail when a document is stale
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hadenlabs/terraform-supabase/internal/docs"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+func main() {
+	check := flag.Bool("check", false, "report stale documents without rewriting them")
+	root := flag.String("root", "", "repository root (defaults to the closest parent holding go.mod and modules/)")
+	flag.Parse()
+
+	if *root == "" {
+		found, err := modules.Root()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		*root = found
+	}
+
+	stale, err := docs.Generate(*root, *check)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for _, file := range stale {
+		if *check {
+			fmt.Printf("stale: %s\n", file)
+			continue
+		}
+		fmt.Printf("updated: %s\n", file)
+	}
+	if *check && len(stale) > 0 {
+		fmt.Fprintln(os.Stderr, "module documentation is stale, run `go run ./cmd/readme`")
+		os.Exit(1)
+	}
+}
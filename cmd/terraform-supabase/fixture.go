@@ -0,0 +1,54 @@
+package main
+
+import (
+	"io"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/fixture"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
+)
+
+// fixtures builds fresh variables for each module.
+var fixtures = map[string]func() map[string]interface{}{
+	"project": func() map[string]interface{} {
+		return fixture.NewProject().ToMap()
+	},
+	"apikey": func() map[string]interface{} {
+		return fixture.NewAPIKey().ToMap()
+	},
+}
+
+func runFixture(args []string, stdout, stderr io.Writer) int {
+	fs, output := newFlagSet("fixture", stderr)
+	name := fs.String("module", "", "module to generate variables for")
+	root := rootFlag(fs)
+	if code, ok := parse(fs, output, args); !ok {
+		return code
+	}
+
+	module, err := findModule(*root, *name)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	fixture, ok := fixtures[module.Name]
+	if !ok {
+		return fail(stderr, errors.Errorf(errors.ErrorNotImplemented, "no fixture for module %s", module.Name))
+	}
+	vars := fixture()
+	if err := module.Validate(vars); err != nil {
+		return fail(stderr, err)
+	}
+
+	format := tfvars.FormatHCL
+	if *output == outputJSON {
+		format = tfvars.FormatJSON
+	}
+	data, err := tfvars.EncodeFormat(vars, format)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	if _, err := stdout.Write(data); err != nil {
+		return fail(stderr, err)
+	}
+	return exitOK
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hadenlabs/terraform-supabase/internal/lint"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+// lintReport is the JSON report of the lint command.
+type lintReport struct {
+	Findings []lintFinding `json:"findings"`
+}
+
+type lintFinding struct {
+	Rule     string        `json:"rule"`
+	Severity lint.Severity `json:"severity"`
+	Module   string        `json:"module"`
+	File     string        `json:"file"`
+	Line     int           `json:"line"`
+	Column   int           `json:"column"`
+	Message  string        `json:"message"`
+}
+
+func runLint(args []string, stdout, stderr io.Writer) int {
+	fs, output := newFlagSet("lint", stderr)
+	name := fs.String("module", "", "module to check (defaults to every module)")
+	root := rootFlag(fs)
+	if code, ok := parseFormats(fs, output, args, outputText, outputJSON, outputSARIF); !ok {
+		return code
+	}
+
+	if *root == "" {
+		found, err := modules.Root()
+		if err != nil {
+			return fail(stderr, err)
+		}
+		*root = found
+	}
+	rules := lint.Rules()
+	var findings []lint.Finding
+	if *name != "" {
+		module, err := findModule(*root, *name)
+		if err != nil {
+			return fail(stderr, err)
+		}
+		findings = lint.Lint(module, rules...)
+	} else {
+		all, err := lint.LintAll(*root, rules...)
+		if err != nil {
+			return fail(stderr, err)
+		}
+		findings = all
+	}
+	findings = lint.Relative(*root, findings)
+
+	var err error
+	switch *output {
+	case outputSARIF:
+		err = lint.WriteSARIF(stdout, rules, findings)
+	case outputJSON:
+		report := lintReport{Findings: []lintFinding{}}
+		for _, f := range findings {
+			report.Findings = append(report.Findings, lintFinding{
+				Rule:     f.Rule,
+				Severity: f.Severity,
+				Module:   f.Module,
+				File:     f.Range.Filename,
+				Line:     f.Range.Start.Line,
+				Column:   f.Range.Start.Column,
+				Message:  f.Message,
+			})
+		}
+		err = writeJSON(stdout, report)
+	default:
+		err = lint.WriteText(stdout, findings)
+		if err == nil && len(findings) == 0 {
+			_, err = fmt.Fprintln(stdout, "no findings")
+		}
+	}
+	if err != nil {
+		return fail(stderr, err)
+	}
+	if lint.HasErrors(findings) {
+		return exitError
+	}
+	return exitOK
+}
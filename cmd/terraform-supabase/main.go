@@ -0,0 +1,140 @@
+// Command terraform-supabase bundles the helpers used to work on the modules of this repository.
+//
+//	terraform-supabase fixture -module project           # print a valid terraform.tfvars
+//	terraform-supabase validate -module project FILE      # check a tfvars file against a module
+//	terraform-supabase sweep -org ORG_ID -delete          # delete leaked test projects
+//	terraform-supabase reconcile -run RUN_ID              # list ledger resources that still exist
+//	terraform-supabase lint -o sarif                      # check module conventions
+//	terraform-supabase version                            # print the build version
+//
+// Every subcommand accepts -o text|json, lint also accepts -o sarif.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+
+	outputText  = "text"
+	outputJSON  = "json"
+	outputSARIF = "sarif"
+)
+
+// command is a subcommand of the tool.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) int
+}
+
+func commands() []command {
+	return []command{
+		{"fixture", "print valid variables for a module", runFixture},
+		{"validate", "check a tfvars file against a module", runValidate},
+		{"sweep", "delete leaked test projects", runSweep},
+		{"reconcile", "list ledger resources that still exist", runReconcile},
+		{"lint", "check module conventions", runLint},
+		{"version", "print the version", runVersion},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		usage(stderr)
+		return exitUsage
+	}
+	for _, cmd := range commands() {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout, stderr)
+		}
+	}
+	fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+	usage(stderr)
+	return exitUsage
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: terraform-supabase <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+// newFlagSet returns a flag set for a subcommand with the shared -o flag.
+func newFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	output := fs.String("o", outputText, "output format: text or json")
+	return fs, output
+}
+
+// parse parses args and checks the -o flag; ok is false when the command should exit with code.
+func parse(fs *flag.FlagSet, output *string, args []string) (code int, ok bool) {
+	return parseFormats(fs, output, args, outputText, outputJSON)
+}
+
+// parseFormats is parse for commands accepting other -o formats than text and json.
+func parseFormats(fs *flag.FlagSet, output *string, args []string, formats ...string) (code int, ok bool) {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp { //nolint:errorlint
+			return exitOK, false
+		}
+		return exitUsage, false
+	}
+	for _, format := range formats {
+		if *output == format {
+			return exitOK, true
+		}
+	}
+	last := len(formats) - 1
+	fmt.Fprintf(fs.Output(), "invalid -o %q: want %s or %s\n", *output, strings.Join(formats[:last], ", "), formats[last])
+	return exitUsage, false
+}
+
+// rootFlag registers the -root flag shared by the commands reading modules.
+func rootFlag(fs *flag.FlagSet) *string {
+	return fs.String("root", "", "repository root (defaults to the closest parent holding go.mod and modules/)")
+}
+
+func findModule(root, name string) (*modules.Module, error) {
+	if name == "" {
+		return nil, errors.New(errors.ErrorInvalidArgument, "-module is required")
+	}
+	if root == "" {
+		found, err := modules.Root()
+		if err != nil {
+			return nil, err
+		}
+		root = found
+	}
+	return modules.Find(root, name)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func fail(stderr io.Writer, err error) int {
+	fmt.Fprintln(stderr, "error:", err)
+	return exitError
+}
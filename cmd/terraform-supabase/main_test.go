@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/ledger"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/mockapi"
+	"github.com/hadenlabs/terraform-supabase/internal/version"
+)
+
+func execute(args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = run(args, &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRun_Usage(t *testing.T) {
+	t.Parallel()
+
+	code, _, stderr := execute()
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, "fixture")
+
+	code, _, stderr = execute("deploy")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, `unknown command "deploy"`)
+}
+
+func TestFixtureValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, module := range []string{"project", "apikey"} {
+		for _, format := range []string{outputText, outputJSON} {
+			t.Run(module+"/"+format, func(t *testing.T) {
+				t.Parallel()
+
+				code, stdout, stderr := execute("fixture", "-module", module, "-o", format)
+				require.Equal(t, exitOK, code, stderr)
+
+				file := filepath.Join(t.TempDir(), "terraform.tfvars")
+				if format == outputJSON {
+					file += ".json"
+				}
+				require.NoError(t, os.WriteFile(file, []byte(stdout), 0o600))
+
+				code, stdout, stderr = execute("validate", "-module", module, file)
+				assert.Equal(t, exitOK, code, stderr)
+				assert.Contains(t, stdout, "valid for module "+module)
+			})
+		}
+	}
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "terraform.tfvars")
+	require.NoError(t, os.WriteFile(file, []byte("name = \"key\"\nproject_ref = \"abc\"\n"), 0o600))
+
+	code, stdout, _ := execute("validate", "-module", "apikey", "-o", "json", file)
+	assert.Equal(t, exitError, code)
+
+	report := validation{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+	assert.False(t, report.Valid)
+	assert.ElementsMatch(t, []violation{
+		{Field: "project_ref", Description: "unknown variable"},
+		{Field: "project_id", Description: "required"},
+	}, report.Violations)
+}
+
+func TestVersion(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, _ := execute("version", "-o", "json")
+	require.Equal(t, exitOK, code)
+
+	got := version.BuildInfo{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &got))
+	assert.Equal(t, version.Get(), got)
+}
+
+func TestSweep(t *testing.T) {
+	t.Setenv("TF_GUARD_ORGANIZATIONS", "hadenlabs")
+
+	server := mockapi.New()
+	defer server.Close()
+
+	old := time.Now().Add(-2 * time.Hour)
+	server.AddProject(management.Project{ID: "leaked", OrganizationID: "hadenlabs", Name: faker.TestNamePrefix + "leaked", CreatedAt: old})
+	server.AddProject(management.Project{ID: "running", OrganizationID: "hadenlabs", Name: faker.TestNamePrefix + "running", CreatedAt: time.Now()})
+	server.AddProject(management.Project{ID: "production", OrganizationID: "hadenlabs", Name: "production", CreatedAt: old})
+	server.AddProject(management.Project{ID: "other", OrganizationID: "other", Name: faker.TestNamePrefix + "other", CreatedAt: old})
+
+	args := []string{"sweep", "-api-url", server.URL, "-token", mockapi.Token, "-org", "hadenlabs"}
+
+	code, stdout, stderr := execute(args...)
+	require.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "matched  leaked "+faker.TestNamePrefix+"leaked\n", stdout, "projects are only listed without -delete")
+	assert.Len(t, server.Projects(), 4)
+
+	code, stdout, stderr = execute(append(args, "-delete", "-o", "json")...)
+	require.Equal(t, exitOK, code, stderr)
+	results := []swept{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &results))
+	require.Len(t, results, 1)
+	assert.Equal(t, "leaked", results[0].ID)
+	assert.True(t, results[0].Deleted)
+
+	refs := []string{}
+	for _, project := range server.Projects() {
+		refs = append(refs, project.ID)
+	}
+	assert.Equal(t, []string{"other", "production", "running"}, refs)
+}
+
+func TestSweep_Refused(t *testing.T) {
+	t.Parallel()
+
+	server := mockapi.New()
+	defer server.Close()
+	server.AddProject(management.Project{ID: "production", OrganizationID: "acme-prod", Name: "app", CreatedAt: time.Now().Add(-2 * time.Hour)})
+	args := []string{"sweep", "-api-url", server.URL, "-token", mockapi.Token, "-delete"}
+
+	cases := []struct {
+		name    string
+		args    []string
+		message string
+	}{
+		{name: "no organization", message: "-org is required"},
+		{name: "organization not allowed", args: []string{"-org", "acme-prod"}, message: `organization "acme-prod" is not in TF_GUARD_ORGANIZATIONS`},
+		{name: "production prefix", args: []string{"-org", "hadenlabs", "-prefix", "a"}, message: `-prefix "a" must start with "` + faker.TestNamePrefix + `"`},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, stdout, stderr := execute(append(append([]string{}, args...), tc.args...)...)
+			assert.Equal(t, exitError, code)
+			assert.Empty(t, stdout)
+			assert.Contains(t, stderr, tc.message)
+		})
+	}
+	assert.Len(t, server.Projects(), 1)
+}
+
+func TestReconcile(t *testing.T) {
+	t.Parallel()
+
+	server := mockapi.New()
+	defer server.Close()
+	server.AddProject(management.Project{ID: "leaked", OrganizationID: "org", Name: faker.TestNamePrefix + "leaked"})
+
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+	module := "project/test/project-basic"
+	require.NoError(t, ledger.New(path, "run-1").Append(
+		ledger.Record{Test: "TestLeak", Module: module, Type: ledger.TypeProject, ID: "leaked", ProjectRef: "leaked", Action: ledger.ActionCreate},
+		ledger.Record{Test: "TestGone", Module: module, Type: ledger.TypeProject, ID: "gone", ProjectRef: "gone", Action: ledger.ActionCreate},
+	))
+	args := []string{"reconcile", "-ledger", path, "-api-url", server.URL, "-token", mockapi.Token}
+
+	code, stdout, stderr := execute(args...)
+	require.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "orphan     supabase_project leaked project=leaked run=run-1 test=TestLeak module=project/test/project-basic\n", stdout)
+
+	code, stdout, stderr = execute(append(args, "-o", "json")...)
+	require.Equal(t, exitOK, code, stderr)
+	orphans := []ledger.Orphan{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &orphans))
+	require.Len(t, orphans, 1)
+	assert.Equal(t, "leaked", orphans[0].ID)
+
+	code, stdout, stderr = execute(append(args, "-run", "run-2")...)
+	require.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "no orphans\n", stdout)
+
+	code, _, stderr = execute("reconcile", "-ledger", filepath.Join(t.TempDir(), "missing.jsonl"), "-token", mockapi.Token)
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "not found")
+}
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, stderr := execute("lint")
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "no findings\n", stdout)
+
+	code, stdout, stderr = execute("lint", "-module", "apikey", "-o", "sarif")
+	require.Equal(t, exitOK, code, stderr)
+	assert.Contains(t, stdout, `"version": "2.1.0"`)
+
+	code, _, stderr = execute("lint", "-o", "xml")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, "want text, json or sarif")
+}
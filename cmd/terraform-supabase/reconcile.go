@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/ledger"
+)
+
+func runReconcile(args []string, stdout, stderr io.Writer) int {
+	fs, output := newFlagSet("reconcile", stderr)
+	path := fs.String("ledger", "", "ledger file (defaults to TF_LEDGER_PATH)")
+	runID := fs.String("run", "", "only reconcile resources of this run ID")
+	apiURL := fs.String("api-url", "", "Management API URL (defaults to SUPABASE_API_URL)")
+	token := fs.String("token", "", "Management API access token (defaults to SUPABASE_ACCESS_TOKEN)")
+	if code, ok := parse(fs, output, args); !ok {
+		return code
+	}
+
+	conf := config.Must()
+	if *path != "" {
+		conf.Ledger.Path = *path
+	}
+	if *apiURL != "" {
+		conf.Supabase.APIURL = *apiURL
+	}
+	if *token != "" {
+		conf.Supabase.AccessToken = *token
+	}
+	if conf.Supabase.AccessToken == "" {
+		return fail(stderr, errors.New(errors.ErrorInvalidArgument, "SUPABASE_ACCESS_TOKEN or -token is required"))
+	}
+
+	records, err := ledger.Read(ledger.FromConfig(conf).Path)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	orphans, err := ledger.Reconcile(context.Background(), management.NewFromConfig(conf), records, *runID)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	if *output == outputJSON {
+		if err := writeJSON(stdout, orphans); err != nil {
+			return fail(stderr, err)
+		}
+		return exitOK
+	}
+	for _, orphan := range orphans {
+		state := "orphan    "
+		if orphan.Unverified {
+			state = "unverified"
+		}
+		fmt.Fprintf(stdout, "%s %s %s project=%s run=%s test=%s module=%s\n",
+			state, orphan.Type, orphan.ID, orphan.ProjectRef, orphan.RunID, orphan.Test, orphan.Module)
+	}
+	if len(orphans) == 0 {
+		fmt.Fprintln(stdout, "no orphans")
+	}
+	return exitOK
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// swept is a project matched by the sweep command.
+type swept struct {
+	management.Project
+	Deleted bool   `json:"deleted"`
+	Error   string `json:"error,omitempty"`
+}
+
+func runSweep(args []string, stdout, stderr io.Writer) int {
+	fs, output := newFlagSet("sweep", stderr)
+	org := fs.String("org", "", "organization to sweep, one of TF_GUARD_ORGANIZATIONS (required)")
+	prefix := fs.String("prefix", faker.TestNamePrefix, "only sweep projects whose name starts with this prefix, itself starting with "+faker.TestNamePrefix)
+	olderThan := fs.Duration("older-than", time.Hour, "only sweep projects created at least this long ago")
+	del := fs.Bool("delete", false, "delete the matched projects instead of only listing them")
+	apiURL := fs.String("api-url", "", "Management API URL (defaults to SUPABASE_API_URL)")
+	token := fs.String("token", "", "Management API access token (defaults to SUPABASE_ACCESS_TOKEN)")
+	if code, ok := parse(fs, output, args); !ok {
+		return code
+	}
+	if !strings.HasPrefix(*prefix, faker.TestNamePrefix) {
+		return fail(stderr, errors.Errorf(errors.ErrorInvalidArgument, "-prefix %q must start with %q", *prefix, faker.TestNamePrefix))
+	}
+
+	if *org == "" {
+		return fail(stderr, errors.New(errors.ErrorInvalidArgument, "-org is required"))
+	}
+
+	conf := config.Must()
+	if !contains(conf.Guard.Organizations, *org) {
+		return fail(stderr, errors.Errorf(errors.ErrorPermissionDenied, "organization %q is not in TF_GUARD_ORGANIZATIONS [%s]",
+			*org, strings.Join(conf.Guard.Organizations, ", ")))
+	}
+	if *apiURL != "" {
+		conf.Supabase.APIURL = *apiURL
+	}
+	if *token != "" {
+		conf.Supabase.AccessToken = *token
+	}
+	if conf.Supabase.AccessToken == "" {
+		return fail(stderr, errors.New(errors.ErrorInvalidArgument, "SUPABASE_ACCESS_TOKEN or -token is required"))
+	}
+	client := management.NewFromConfig(conf)
+
+	ctx := context.Background()
+	projects, err := client.ListProjects(ctx)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	cutoff := time.Now().Add(-*olderThan)
+	results := []swept{}
+	for _, project := range projects {
+		if project.OrganizationID != *org {
+			continue
+		}
+		if !strings.HasPrefix(project.Name, *prefix) || project.CreatedAt.After(cutoff) {
+			continue
+		}
+		result := swept{Project: project}
+		if *del {
+			if err := client.DeleteProject(ctx, project.ID); err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Deleted = true
+			}
+		}
+		results = append(results, result)
+	}
+
+	code := exitOK
+	for _, result := range results {
+		if result.Error != "" {
+			code = exitError
+		}
+	}
+
+	if *output == outputJSON {
+		if err := writeJSON(stdout, results); err != nil {
+			return fail(stderr, err)
+		}
+		return code
+	}
+	for _, result := range results {
+		switch {
+		case result.Error != "":
+			fmt.Fprintf(stdout, "failed   %s %s: %s\n", result.ID, result.Name, result.Error)
+		case result.Deleted:
+			fmt.Fprintf(stdout, "deleted  %s %s\n", result.ID, result.Name)
+		default:
+			fmt.Fprintf(stdout, "matched  %s %s\n", result.ID, result.Name)
+		}
+	}
+	if len(results) == 0 {
+		fmt.Fprintln(stdout, "no leaked projects")
+	}
+	return code
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
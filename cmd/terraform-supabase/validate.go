@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
+)
+
+// validation is the JSON report of the validate command.
+type validation struct {
+	Module     string      `json:"module"`
+	File       string      `json:"file"`
+	Valid      bool        `json:"valid"`
+	Violations []violation `json:"violations"`
+}
+
+type violation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	fs, output := newFlagSet("validate", stderr)
+	name := fs.String("module", "", "module the variables are meant for")
+	root := rootFlag(fs)
+	if code, ok := parse(fs, output, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "usage: terraform-supabase validate -module NAME [-o text|json] FILE")
+		return exitUsage
+	}
+	file := fs.Arg(0)
+
+	module, err := findModule(*root, *name)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	vars, err := tfvars.Read(file)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	report := validation{Module: module.Name, File: file, Valid: true, Violations: []violation{}}
+	if err := module.Validate(vars); err != nil {
+		ie := &errors.Error{}
+		if !errors.As(err, &ie) || len(ie.FieldViolations()) == 0 {
+			return fail(stderr, err)
+		}
+		report.Valid = false
+		for _, v := range ie.FieldViolations() {
+			report.Violations = append(report.Violations, violation{Field: v.Field, Description: v.Description})
+		}
+	}
+
+	if *output == outputJSON {
+		if err := writeJSON(stdout, report); err != nil {
+			return fail(stderr, err)
+		}
+	} else {
+		for _, v := range report.Violations {
+			fmt.Fprintf(stdout, "%s: %s: %s\n", file, v.Field, v.Description)
+		}
+		if report.Valid {
+			fmt.Fprintf(stdout, "%s: valid for module %s\n", file, module.Name)
+		}
+	}
+	if !report.Valid {
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hadenlabs/terraform-supabase/internal/version"
+)
+
+func runVersion(args []string, stdout, stderr io.Writer) int {
+	fs, output := newFlagSet("version", stderr)
+	if code, ok := parse(fs, output, args); !ok {
+		return code
+	}
+
+	if *output == outputJSON {
+		if err := version.Get().WriteJSON(stdout); err != nil {
+			return fail(stderr, err)
+		}
+		return exitOK
+	}
+	fmt.Fprintln(stdout, version.Full())
+	return exitOK
+}
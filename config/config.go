@@ -12,8 +12,16 @@ import (
 
 // Config struct field.
 type Config struct {
-	App App
-	Log Log
+	App       App
+	Log       Log
+	Supabase  Supabase
+	Matrix    Matrix
+	Terraform Terraform
+	Guard     Guard
+	Preflight Preflight
+	Ledger    Ledger
+	Property  Property
+	Golden    Golden
 }
 
 const (
@@ -14,3 +14,86 @@ func TestInitialize(t *testing.T) {
 func TestNewConfig(t *testing.T) {
 	assert.IsType(t, &Config{}, New())
 }
+
+func TestSupabaseFromEnv(t *testing.T) {
+	t.Setenv("SUPABASE_API_URL", "http://127.0.0.1:8080")
+	t.Setenv("SUPABASE_ACCESS_TOKEN", "sbp_test")
+	conf := Initialize()
+	assert.Equal(t, "http://127.0.0.1:8080", conf.Supabase.APIURL)
+	assert.Equal(t, "sbp_test", conf.Supabase.AccessToken)
+}
+
+func TestMatrixFromEnv(t *testing.T) {
+	t.Setenv("TF_MATRIX_TERRAFORM_VERSIONS", "1.5.7,1.9.8")
+	t.Setenv("TF_MATRIX_PROVIDERS", "supabase@1.6.0,supabase@1.7.0")
+	conf := Initialize()
+	assert.Equal(t, []string{"1.5.7", "1.9.8"}, conf.Matrix.TerraformVersions)
+	assert.Equal(t, []string{"supabase@1.6.0", "supabase@1.7.0"}, conf.Matrix.Providers)
+}
+
+func TestTerraformFromEnv(t *testing.T) {
+	t.Setenv("TF_PROVIDER_MIRROR", "/var/cache/terraform/providers")
+	t.Setenv("TF_UPGRADE", "true")
+	t.Setenv("TF_UPGRADE_FROM", "v0.4.0")
+	conf := Initialize()
+	assert.Equal(t, "/var/cache/terraform/providers", conf.Terraform.ProviderMirror)
+	assert.True(t, conf.Terraform.Upgrade)
+	assert.Equal(t, "v0.4.0", conf.Terraform.UpgradeFrom)
+}
+
+func TestGuardFromEnv(t *testing.T) {
+	conf := Initialize()
+	assert.Empty(t, conf.Guard.Organizations, "nothing is allowed until TF_GUARD_ORGANIZATIONS is set")
+	assert.False(t, conf.Guard.Override)
+
+	t.Setenv("TF_GUARD_ORGANIZATIONS", "hadenlabs,hadenlabs-ci")
+	t.Setenv("TF_GUARD_OVERRIDE", "true")
+	conf = Initialize()
+	assert.Equal(t, []string{"hadenlabs", "hadenlabs-ci"}, conf.Guard.Organizations)
+	assert.True(t, conf.Guard.Override)
+}
+
+func TestPreflightFromEnv(t *testing.T) {
+	conf := Initialize()
+	assert.Equal(t, []string{"nano", "micro", "small"}, conf.Preflight.InstanceSizes)
+	assert.Zero(t, conf.Preflight.MaxProjects)
+	assert.False(t, conf.Preflight.Fail)
+
+	t.Setenv("TF_PREFLIGHT_INSTANCE_SIZES", "micro")
+	t.Setenv("TF_PREFLIGHT_MAX_PROJECTS", "5")
+	t.Setenv("TF_PREFLIGHT_FAIL", "true")
+	conf = Initialize()
+	assert.Equal(t, []string{"micro"}, conf.Preflight.InstanceSizes)
+	assert.Equal(t, 5, conf.Preflight.MaxProjects)
+	assert.True(t, conf.Preflight.Fail)
+}
+
+func TestLedgerFromEnv(t *testing.T) {
+	conf := Initialize()
+	assert.Empty(t, conf.Ledger.Path)
+	assert.Empty(t, conf.Ledger.RunID)
+
+	t.Setenv("TF_LEDGER_PATH", "/var/log/terraform-supabase/ledger.jsonl")
+	t.Setenv("TF_LEDGER_RUN_ID", "1234")
+	conf = Initialize()
+	assert.Equal(t, "/var/log/terraform-supabase/ledger.jsonl", conf.Ledger.Path)
+	assert.Equal(t, "1234", conf.Ledger.RunID)
+}
+
+func TestPropertyFromEnv(t *testing.T) {
+	conf := Initialize()
+	assert.Zero(t, conf.Property.Seed)
+
+	t.Setenv("TF_PROPERTY_SEED", "42")
+	conf = Initialize()
+	assert.Equal(t, int64(42), conf.Property.Seed)
+}
+
+func TestGoldenFromEnv(t *testing.T) {
+	conf := Initialize()
+	assert.False(t, conf.Golden.Update)
+
+	t.Setenv("UPDATE_GOLDEN", "true")
+	conf = Initialize()
+	assert.True(t, conf.Golden.Update)
+}
@@ -0,0 +1,7 @@
+package config
+
+// Golden struct field.
+type Golden struct {
+	// Update rewrites golden files and checked-in snapshots instead of comparing against them.
+	Update bool `env:"UPDATE_GOLDEN"`
+}
@@ -0,0 +1,9 @@
+package config
+
+// Guard struct field.
+type Guard struct {
+	// Organizations are the organizations tests may apply to and destroy in, none until set.
+	Organizations []string `env:"TF_GUARD_ORGANIZATIONS" envSeparator:","`
+	// Override disables the guard, for a deliberate run against any organization or project.
+	Override bool `env:"TF_GUARD_OVERRIDE"`
+}
@@ -0,0 +1,10 @@
+package config
+
+// Ledger struct field.
+type Ledger struct {
+	// Path is the JSON Lines file tests record the resources they create and destroy in, a file in the
+	// temporary directory when empty.
+	Path string `env:"TF_LEDGER_PATH"`
+	// RunID identifies the CI run in the ledger, such as the job ID, a random ID per test binary when empty.
+	RunID string `env:"TF_LEDGER_RUN_ID"`
+}
@@ -0,0 +1,12 @@
+package config
+
+// Matrix struct field.
+type Matrix struct {
+	// TerraformVersions are run with the binaries terraform_<version> cached in BinDir.
+	TerraformVersions []string `env:"TF_MATRIX_TERRAFORM_VERSIONS" envSeparator:","`
+	// Providers are provider versions as name@version, e.g. supabase@1.6.0.
+	Providers []string `env:"TF_MATRIX_PROVIDERS" envSeparator:","`
+	BinDir    string   `env:"TF_MATRIX_BIN_DIR"`
+	PluginDir string   `env:"TF_MATRIX_PLUGIN_DIR"`
+	Report    string   `env:"TF_MATRIX_REPORT"`
+}
@@ -0,0 +1,11 @@
+package config
+
+// Preflight struct field.
+type Preflight struct {
+	// InstanceSizes are the instance sizes tests may provision, larger ones cost money.
+	InstanceSizes []string `env:"TF_PREFLIGHT_INSTANCE_SIZES" envSeparator:"," envDefault:"nano,micro,small"`
+	// MaxProjects caps the active projects of the organization, 0 leaves only the plan limit.
+	MaxProjects int `env:"TF_PREFLIGHT_MAX_PROJECTS"`
+	// Fail fails tests that would exceed a budget or limit instead of skipping them.
+	Fail bool `env:"TF_PREFLIGHT_FAIL"`
+}
@@ -0,0 +1,7 @@
+package config
+
+// Property struct field.
+type Property struct {
+	// Seed makes property checks reproducible, such as the seed a failure printed, random when 0.
+	Seed int64 `env:"TF_PROPERTY_SEED"`
+}
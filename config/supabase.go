@@ -0,0 +1,7 @@
+package config
+
+// Supabase struct field.
+type Supabase struct {
+	AccessToken string `env:"SUPABASE_ACCESS_TOKEN"`
+	APIURL      string `env:"SUPABASE_API_URL" envDefault:"https://api.supabase.com"`
+}
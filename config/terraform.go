@@ -0,0 +1,11 @@
+package config
+
+// Terraform struct field.
+type Terraform struct {
+	// ProviderMirror is a filesystem provider mirror every provider is installed from.
+	ProviderMirror string `env:"TF_PROVIDER_MIRROR"`
+	// Upgrade makes terraform init upgrade providers, which reaches the registry.
+	Upgrade bool `env:"TF_UPGRADE"`
+	// UpgradeFrom is the git ref upgrade tests apply before switching to the working tree, the previous tag by default.
+	UpgradeFrom string `env:"TF_UPGRADE_FROM"`
+}
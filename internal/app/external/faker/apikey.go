@@ -1,15 +1,56 @@
 package faker
 
 import (
+	"crypto/rand"
+	"math/big"
+	"regexp"
 	"strings"
+	"sync"
 
 	fakerTag "github.com/bxcodec/faker/v3"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// API key types of the Supabase API
+const (
+	APIKeyTypePublishable = "publishable"
+	APIKeyTypeSecret      = "secret"
+)
+
+// Bounds of the API key names accepted by Supabase
+const (
+	APIKeyNameMinLength = 4
+	APIKeyNameMaxLength = 64
 )
 
+// apiKeyNamePattern is the charset of API key names: lowercase letters, digits and
+// underscores, starting with a letter
+var apiKeyNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// apiKeyNameSuffixChars are the characters of the random suffix keeping names unique
+const apiKeyNameSuffixChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// apiKeyNameSuffixLength is the length of the random suffix keeping names unique
+const apiKeyNameSuffixLength = 6
+
+// apiKeyTypes are the API key types
+var apiKeyTypes = []string{APIKeyTypePublishable, APIKeyTypeSecret}
+
+// jwtRoles are the Postgres roles a secret key's JWT template can carry
+var jwtRoles = []string{"service_role", "authenticated", "anon"}
+
+// apiKeyNames holds every name handed out in this run
+var apiKeyNames sync.Map
+
 // FakeApiKey interface defines methods for generating fake ApiKey data
 type FakeApiKey interface {
-	Name() string        // Name generates a fake ApiKey name
-	Description() string // Description generates a fake Description
+	Name() string                              // Name generates a fake ApiKey name, unique within the run
+	PublishableName() string                   // PublishableName generates a fake name for a publishable key
+	SecretName() string                        // SecretName generates a fake name for a secret key
+	Type() string                              // Type generates a fake ApiKey type
+	Description() string                       // Description generates a fake Description
+	SecretJWTTemplate() map[string]interface{} // SecretJWTTemplate generates a fake secret_jwt_template
 }
 
 type fakeApiKey struct{}
@@ -19,12 +60,97 @@ func ApiKey() FakeApiKey {
 	return fakeApiKey{}
 }
 
-// Name generates a fake ApiKey name
+// Name generates a fake ApiKey name, unique within the run
 func (p fakeApiKey) Name() string {
-	return strings.ToLower(fakerTag.Name())
+	return uniqueAPIKeyName(sanitizeAPIKeyName(fakerTag.FirstName() + "_" + fakerTag.LastName()))
+}
+
+// PublishableName generates a fake name for a publishable key
+func (p fakeApiKey) PublishableName() string {
+	return uniqueAPIKeyName(APIKeyTypePublishable + "_" + sanitizeAPIKeyName(fakerTag.LastName()))
+}
+
+// SecretName generates a fake name for a secret key
+func (p fakeApiKey) SecretName() string {
+	return uniqueAPIKeyName(APIKeyTypeSecret + "_" + sanitizeAPIKeyName(fakerTag.LastName()))
 }
 
-// OrganizationID generates a fake organization ID
+// Type generates a fake ApiKey type
+func (p fakeApiKey) Type() string {
+	return pick(apiKeyTypes)
+}
+
+// Description generates a fake Description
 func (p fakeApiKey) Description() string {
 	return fakerTag.Sentence()
 }
+
+// SecretJWTTemplate generates a fake secret_jwt_template
+func (p fakeApiKey) SecretJWTTemplate() map[string]interface{} {
+	return map[string]interface{}{"role": pick(jwtRoles)}
+}
+
+// ValidAPIKeyName reports whether Supabase accepts name as an API key name
+func ValidAPIKeyName(name string) bool {
+	return len(name) >= APIKeyNameMinLength && len(name) <= APIKeyNameMaxLength && apiKeyNamePattern.MatchString(name)
+}
+
+// sanitizeAPIKeyName maps anything outside the API key name charset to underscores
+func sanitizeAPIKeyName(s string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	name := strings.TrimRight(b.String(), "_")
+	switch {
+	case name == "":
+		name = "key"
+	case name[0] < 'a' || name[0] > 'z':
+		name = "key_" + name
+	}
+	return name
+}
+
+// uniqueAPIKeyName appends a random suffix to base, fitting APIKeyNameMaxLength,
+// until the name was not handed out before
+func uniqueAPIKeyName(base string) string {
+	maxBase := APIKeyNameMaxLength - apiKeyNameSuffixLength - 1
+	if len(base) > maxBase {
+		base = strings.TrimRight(base[:maxBase], "_")
+	}
+	for {
+		name := base + "_" + randomString(apiKeyNameSuffixChars, apiKeyNameSuffixLength)
+		if _, loaded := apiKeyNames.LoadOrStore(name, struct{}{}); !loaded {
+			return name
+		}
+	}
+}
+
+func randomString(chars string, length int) string {
+	b := make([]byte, length)
+	for i := range b {
+		num, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			panic(errors.New(errors.ErrorUnknown, err.Error()))
+		}
+		b[i] = chars[num.Int64()]
+	}
+	return string(b)
+}
+
+func pick(values []string) string {
+	num, err := rand.Int(rand.Reader, big.NewInt(int64(len(values))))
+	if err != nil {
+		panic(errors.New(errors.ErrorUnknown, err.Error()))
+	}
+	return values[num.Int64()]
+}
@@ -1,6 +1,7 @@
 package faker
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,9 +10,64 @@ import (
 func TestFakeApiKeyName(t *testing.T) {
 	name := ApiKey().Name()
 	assert.NotEmpty(t, name, name)
+	assert.True(t, ValidAPIKeyName(name), name)
+}
+
+func TestFakeApiKeyName_Unique(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		name := ApiKey().Name()
+		assert.False(t, seen[name], "duplicate name %s", name)
+		assert.True(t, ValidAPIKeyName(name), name)
+		seen[name] = true
+	}
+}
+
+func TestFakeApiKeyTypedNames(t *testing.T) {
+	publishable := ApiKey().PublishableName()
+	assert.True(t, strings.HasPrefix(publishable, APIKeyTypePublishable+"_"), publishable)
+	assert.True(t, ValidAPIKeyName(publishable), publishable)
+
+	secret := ApiKey().SecretName()
+	assert.True(t, strings.HasPrefix(secret, APIKeyTypeSecret+"_"), secret)
+	assert.True(t, ValidAPIKeyName(secret), secret)
+}
+
+func TestFakeApiKeyType(t *testing.T) {
+	assert.Contains(t, apiKeyTypes, ApiKey().Type())
 }
 
 func TestFakeApiKeyDescription(t *testing.T) {
 	description := ApiKey().Description()
 	assert.NotEmpty(t, description, description)
 }
+
+func TestFakeApiKeySecretJWTTemplate(t *testing.T) {
+	template := ApiKey().SecretJWTTemplate()
+	assert.Contains(t, jwtRoles, template["role"])
+}
+
+func TestValidAPIKeyName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"ci_key", true},
+		{"key", false},
+		{"mrs. jane doe", false},
+		{"Backend", false},
+		{"1st_key", false},
+		{"_key", false},
+		{strings.Repeat("a", APIKeyNameMaxLength), true},
+		{strings.Repeat("a", APIKeyNameMaxLength+1), false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.valid, ValidAPIKeyName(tt.name), tt.name)
+	}
+}
+
+func TestSanitizeAPIKeyName(t *testing.T) {
+	assert.Equal(t, "mrs_jane_doe", sanitizeAPIKeyName("Mrs. Jane  Doe"))
+	assert.Equal(t, "key_42", sanitizeAPIKeyName("42"))
+	assert.Equal(t, "key", sanitizeAPIKeyName("..."))
+}
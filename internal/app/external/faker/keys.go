@@ -0,0 +1,168 @@
+package faker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+const (
+	// APIKeyPrefixPublishable starts every publishable key value
+	APIKeyPrefixPublishable = "sb_publishable_"
+
+	// APIKeyPrefixSecret starts every secret key value
+	APIKeyPrefixSecret = "sb_secret_"
+
+	// TestJWTSecret signs the legacy JWTs generated for tests
+	TestJWTSecret = "super-secret-jwt-token-with-at-least-32-characters-long"
+
+	// JWTIssuer is the iss claim of Supabase API key JWTs
+	JWTIssuer = "supabase"
+
+	// Legacy API key roles
+	RoleAnon        = "anon"
+	RoleServiceRole = "service_role"
+
+	// apiKeyValueChars are the characters of the random part of a key value
+	apiKeyValueChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+	// apiKeyValueLength is the length of the random part of a key value
+	apiKeyValueLength = 32
+
+	// legacyJWTLifetime is how long generated legacy JWTs stay valid
+	legacyJWTLifetime = 10 * 365 * 24 * time.Hour
+)
+
+// apiKeyValuePattern matches the value of publishable and secret keys
+var apiKeyValuePattern = regexp.MustCompile(`^sb_(publishable|secret)_[A-Za-z0-9]+$`)
+
+// jwtHeader is the header of HS256 tokens, pre-encoded
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims are the claims of a Supabase API key JWT
+type Claims struct {
+	Issuer    string `json:"iss"`
+	Ref       string `json:"ref"`
+	Role      string `json:"role"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// APIKeyValue generates the value of a publishable or secret key
+func APIKeyValue(keyType string) string {
+	prefix := APIKeyPrefixPublishable
+	if keyType == APIKeyTypeSecret {
+		prefix = APIKeyPrefixSecret
+	}
+	return prefix + randomString(apiKeyValueChars, apiKeyValueLength)
+}
+
+// LegacyJWT generates a legacy anon or service_role key for the project ref, signed with TestJWTSecret
+func LegacyJWT(role, ref string) string {
+	now := time.Now()
+	token, err := SignJWT(Claims{
+		Issuer:    JWTIssuer,
+		Ref:       ref,
+		Role:      role,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(legacyJWTLifetime).Unix(),
+	}, TestJWTSecret)
+	if err != nil {
+		panic(err)
+	}
+	return token
+}
+
+// SignJWT encodes claims as an HS256 JWT signed with secret
+func SignJWT(claims Claims, secret string) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", errors.Wrap(err, errors.ErrorInvalidArgument, "encode claims")
+	}
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + sign(unsigned, secret), nil
+}
+
+// VerifyAPIKey checks that key is a well-formed value of the given key type
+func VerifyAPIKey(key, keyType string) error {
+	if !apiKeyValuePattern.MatchString(key) {
+		return errors.New(errors.ErrorInvalidArgument, "malformed api key")
+	}
+	prefix := APIKeyPrefixPublishable
+	if keyType == APIKeyTypeSecret {
+		prefix = APIKeyPrefixSecret
+	}
+	if !strings.HasPrefix(key, prefix) {
+		return errors.Errorf(errors.ErrorInvalidArgument, "api key is not of type %s", keyType)
+	}
+	return nil
+}
+
+// VerifyJWT checks the signature, issuer and expiry of an HS256 token and returns its claims
+func VerifyJWT(token, secret string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New(errors.ErrorInvalidArgument, "malformed jwt")
+	}
+	header := struct {
+		Alg string `json:"alg"`
+	}{}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Alg != "HS256" {
+		return nil, errors.Errorf(errors.ErrorInvalidArgument, "unexpected jwt algorithm %s", header.Alg)
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(sign(parts[0]+"."+parts[1], secret))) {
+		return nil, errors.New(errors.ErrorInvalidArgument, "invalid jwt signature")
+	}
+	claims := &Claims{}
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return nil, err
+	}
+	if claims.Issuer != JWTIssuer {
+		return nil, errors.Errorf(errors.ErrorInvalidArgument, "unexpected jwt issuer %q", claims.Issuer)
+	}
+	if claims.ExpiresAt <= time.Now().Unix() {
+		return nil, errors.New(errors.ErrorInvalidArgument, "jwt expired")
+	}
+	return claims, nil
+}
+
+// VerifyLegacyJWT checks a legacy key with VerifyJWT and that it carries role and ref
+func VerifyLegacyJWT(token, secret, role, ref string) (*Claims, error) {
+	claims, err := VerifyJWT(token, secret)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Role != role {
+		return nil, errors.Errorf(errors.ErrorInvalidArgument, "jwt role is %q, want %q", claims.Role, role)
+	}
+	if claims.Ref != ref {
+		return nil, errors.Errorf(errors.ErrorInvalidArgument, "jwt ref is %q, want %q", claims.Ref, ref)
+	}
+	return claims, nil
+}
+
+func sign(unsigned, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrorInvalidArgument, "malformed jwt")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.Wrap(err, errors.ErrorInvalidArgument, "malformed jwt")
+	}
+	return nil
+}
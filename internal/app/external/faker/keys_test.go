@@ -0,0 +1,70 @@
+package faker
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+func TestAPIKeyValue(t *testing.T) {
+	publishable := APIKeyValue(APIKeyTypePublishable)
+	assert.True(t, strings.HasPrefix(publishable, APIKeyPrefixPublishable), publishable)
+	assert.NoError(t, VerifyAPIKey(publishable, APIKeyTypePublishable))
+	assert.Error(t, VerifyAPIKey(publishable, APIKeyTypeSecret))
+
+	secret := APIKeyValue(APIKeyTypeSecret)
+	assert.True(t, strings.HasPrefix(secret, APIKeyPrefixSecret), secret)
+	assert.NoError(t, VerifyAPIKey(secret, APIKeyTypeSecret))
+
+	assert.NotEqual(t, secret, APIKeyValue(APIKeyTypeSecret))
+	assert.Error(t, VerifyAPIKey("sb_secret_", APIKeyTypeSecret))
+	assert.Error(t, VerifyAPIKey("eyJhbGciOiJIUzI1NiJ9.e30.x", APIKeyTypeSecret))
+}
+
+func TestLegacyJWT(t *testing.T) {
+	ref := Project().Ref()
+	token := LegacyJWT(RoleServiceRole, ref)
+
+	claims, err := VerifyLegacyJWT(token, TestJWTSecret, RoleServiceRole, ref)
+	require.NoError(t, err)
+	assert.Equal(t, JWTIssuer, claims.Issuer)
+	assert.Greater(t, claims.ExpiresAt, time.Now().Unix())
+
+	_, err = VerifyLegacyJWT(token, TestJWTSecret, RoleAnon, ref)
+	assert.ErrorContains(t, err, "role")
+	_, err = VerifyLegacyJWT(token, TestJWTSecret, RoleServiceRole, "other")
+	assert.ErrorContains(t, err, "ref")
+	_, err = VerifyJWT(token, "wrong-secret")
+	assert.ErrorContains(t, err, "signature")
+}
+
+func TestVerifyJWT_Invalid(t *testing.T) {
+	now := time.Now()
+	expired, err := SignJWT(Claims{Issuer: JWTIssuer, Role: RoleAnon, ExpiresAt: now.Add(-time.Minute).Unix()}, TestJWTSecret)
+	require.NoError(t, err)
+	foreign, err := SignJWT(Claims{Issuer: "acme", Role: RoleAnon, ExpiresAt: now.Add(time.Hour).Unix()}, TestJWTSecret)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{"expired", expired, "expired"},
+		{"issuer", foreign, "issuer"},
+		{"malformed", "not-a-jwt", "malformed"},
+		{"algorithm", "eyJhbGciOiJub25lIn0.e30.", "algorithm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyJWT(tt.token, TestJWTSecret)
+			assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
@@ -2,32 +2,49 @@ package faker
 
 import (
 	"reflect"
+	"sync"
 
 	fakerTag "github.com/bxcodec/faker/v3"
 )
 
-func Generator() {
-	_ = fakerTag.AddProvider("ProjectNameFaker", func(v reflect.Value) (any, error) {
-		return Project().Name(), nil
-	})
-	_ = fakerTag.AddProvider("ProjectOrganizationIDFaker", func(v reflect.Value) (any, error) {
-		return Project().OrganizationID(), nil
-	})
-	_ = fakerTag.AddProvider("ProjectRegionFaker", func(v reflect.Value) (any, error) {
-		return Project().Region(), nil
-	})
-	_ = fakerTag.AddProvider("ProjectInstanceSizeFaker", func(v reflect.Value) (any, error) {
-		return Project().InstanceSize(), nil
-	})
-	_ = fakerTag.AddProvider("ProjectDatabasePasswordFaker", func(v reflect.Value) (any, error) {
-		return Project().DatabasePassword(), nil
-	})
+// providers maps the faker struct tags to their generators, e.g. `faker:"ProjectNameFaker"`
+var providers = map[string]func() string{
+	"ProjectNameFaker":             func() string { return Project().Name() },
+	"ProjectTestNameFaker":         func() string { return Project().TestName() },
+	"ProjectRefFaker":              func() string { return Project().Ref() },
+	"ProjectOrganizationIDFaker":   func() string { return Project().OrganizationID() },
+	"ProjectRegionFaker":           func() string { return Project().Region() },
+	"ProjectInstanceSizeFaker":     func() string { return Project().InstanceSize() },
+	"ProjectDatabasePasswordFaker": func() string { return Project().DatabasePassword() },
 
-	_ = fakerTag.AddProvider("ApiKeyNameFaker", func(v reflect.Value) (any, error) {
-		return ApiKey().Name(), nil
-	})
+	"ApiKeyNameFaker":            func() string { return ApiKey().Name() },
+	"ApiKeyPublishableNameFaker": func() string { return ApiKey().PublishableName() },
+	"ApiKeySecretNameFaker":      func() string { return ApiKey().SecretName() },
+	"ApiKeyTypeFaker":            func() string { return ApiKey().Type() },
+	"ApiKeyDescriptionFaker":     func() string { return ApiKey().Description() },
+}
+
+var generatorOnce sync.Once
+
+func init() {
+	Generator()
+}
 
-	_ = fakerTag.AddProvider("ApiKeyDescriptionFaker", func(v reflect.Value) (any, error) {
-		return ApiKey().Description(), nil
+// Generator registers the providers with bxcodec/faker. It is called on package
+// initialization and safe to call again.
+func Generator() {
+	generatorOnce.Do(func() {
+		for tag, generate := range providers {
+			_ = fakerTag.AddProvider(tag, func(v reflect.Value) (any, error) {
+				return generate(), nil
+			})
+		}
 	})
 }
+
+// FakeData fills every field of the struct pointed to by v, using the providers above
+// for fields tagged with one of their names.
+func FakeData(v interface{}) error {
+	Generator()
+	return fakerTag.FakeData(v)
+}
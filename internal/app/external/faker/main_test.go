@@ -0,0 +1,36 @@
+package faker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tagged struct {
+	Name         string `faker:"ProjectTestNameFaker"`
+	Ref          string `faker:"ProjectRefFaker"`
+	Region       string `faker:"ProjectRegionFaker"`
+	InstanceSize string `faker:"ProjectInstanceSizeFaker"`
+	APIKeyName   string `faker:"ApiKeyNameFaker"`
+}
+
+func TestGenerator_Idempotent(t *testing.T) {
+	Generator()
+	Generator()
+
+	v := tagged{}
+	require.NoError(t, FakeData(&v))
+}
+
+func TestFakeData(t *testing.T) {
+	v := tagged{}
+	require.NoError(t, FakeData(&v))
+
+	assert.True(t, strings.HasPrefix(v.Name, TestNamePrefix), v.Name)
+	assert.Len(t, v.Ref, refLength)
+	assert.Contains(t, regionNames, v.Region)
+	assert.Contains(t, instanceSizes, v.InstanceSize)
+	assert.NotEmpty(t, v.APIKeyName)
+}
@@ -54,9 +54,20 @@ var instanceSizes = []string{
 	"xlarge",
 }
 
+// TestNamePrefix marks resources created by tests so leaked ones can be swept
+const TestNamePrefix = "tftest-"
+
+// refChars are the characters of a Supabase project reference
+const refChars = "abcdefghijklmnopqrstuvwxyz"
+
+// refLength is the length of a Supabase project reference
+const refLength = 20
+
 // FakeProject interface defines methods for generating fake project data
 type FakeProject interface {
 	Name() string             // Name generates a fake project name
+	TestName() string         // TestName generates a fake project name carrying TestNamePrefix
+	Ref() string              // Ref generates a fake project reference
 	OrganizationID() string   // OrganizationID generates a fake organization ID
 	Region() string           // Region generates a fake region
 	InstanceSize() string     // InstanceSize generates a fake instance size
@@ -80,6 +91,24 @@ func (p fakeProject) Name() string {
 	return strings.ToLower(nameuuid)
 }
 
+// TestName generates a fake project name carrying TestNamePrefix
+func (p fakeProject) TestName() string {
+	return TestNamePrefix + p.Name()
+}
+
+// Ref generates a fake project reference
+func (p fakeProject) Ref() string {
+	ref := make([]byte, refLength)
+	for i := range ref {
+		num, err := rand.Int(rand.Reader, big.NewInt(int64(len(refChars))))
+		if err != nil {
+			panic(errors.New(errors.ErrorUnknown, err.Error()))
+		}
+		ref[i] = refChars[num.Int64()]
+	}
+	return string(ref)
+}
+
 // OrganizationID generates a fake organization ID
 func (p fakeProject) OrganizationID() string {
 	// Organization IDs in Supabase are typically like "org-xxxxxx"
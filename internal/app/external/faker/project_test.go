@@ -13,6 +13,19 @@ func TestFakeProjectName(t *testing.T) {
 	assert.Contains(t, projectNames, namePrefix, namePrefix)
 }
 
+func TestFakeProjectTestName(t *testing.T) {
+	name := Project().TestName()
+	assert.True(t, strings.HasPrefix(name, TestNamePrefix), name)
+	namePrefix := strings.Split(strings.TrimPrefix(name, TestNamePrefix), "-")[0]
+	assert.Contains(t, projectNames, namePrefix, namePrefix)
+}
+
+func TestFakeProjectRef(t *testing.T) {
+	ref := Project().Ref()
+	assert.Len(t, ref, 20, "Project reference should be 20 characters long")
+	assert.Equal(t, strings.ToLower(ref), ref, "Project reference should be lowercase")
+}
+
 func TestFakeProjectOrganizationID(t *testing.T) {
 	orgID := Project().OrganizationID()
 	assert.True(t, strings.HasPrefix(orgID, "org-"), "Organization ID should start with 'org-'")
@@ -0,0 +1,84 @@
+package management
+
+import (
+	"context"
+	"net/http"
+)
+
+// API key types returned by the Management API
+const (
+	APIKeyTypeLegacy      = "legacy"
+	APIKeyTypePublishable = "publishable"
+	APIKeyTypeSecret      = "secret"
+)
+
+// SecretJWTTemplate is the JWT template of a secret key.
+type SecretJWTTemplate struct {
+	Role string `json:"role"`
+}
+
+// APIKey is an API key as returned by the Management API.
+type APIKey struct {
+	ID                string             `json:"id,omitempty"`
+	Name              string             `json:"name"`
+	Type              string             `json:"type"`
+	Description       *string            `json:"description,omitempty"`
+	APIKey            string             `json:"api_key,omitempty"`
+	SecretJWTTemplate *SecretJWTTemplate `json:"secret_jwt_template,omitempty"`
+}
+
+// CreateAPIKey is the body of an API key creation request.
+type CreateAPIKey struct {
+	Type              string             `json:"type"`
+	Name              string             `json:"name"`
+	Description       *string            `json:"description,omitempty"`
+	SecretJWTTemplate *SecretJWTTemplate `json:"secret_jwt_template,omitempty"`
+}
+
+// UpdateAPIKey is the body of an API key update request, nil fields are left unchanged.
+type UpdateAPIKey struct {
+	Name              *string            `json:"name,omitempty"`
+	Description       *string            `json:"description,omitempty"`
+	SecretJWTTemplate *SecretJWTTemplate `json:"secret_jwt_template,omitempty"`
+}
+
+// ListAPIKeys returns the API keys of a project, including their values.
+func (c *Client) ListAPIKeys(ctx context.Context, ref string) ([]APIKey, error) {
+	keys := []APIKey{}
+	if err := c.do(ctx, http.MethodGet, projectPath(ref, "api-keys")+"?reveal=true", nil, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GetAPIKey returns a single API key of a project, including its value.
+func (c *Client) GetAPIKey(ctx context.Context, ref, id string) (*APIKey, error) {
+	key := &APIKey{}
+	if err := c.do(ctx, http.MethodGet, projectPath(ref, "api-keys", id)+"?reveal=true", nil, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// CreateAPIKey creates an API key in a project.
+func (c *Client) CreateAPIKey(ctx context.Context, ref string, in CreateAPIKey) (*APIKey, error) {
+	key := &APIKey{}
+	if err := c.do(ctx, http.MethodPost, projectPath(ref, "api-keys")+"?reveal=true", in, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// UpdateAPIKey updates an API key of a project.
+func (c *Client) UpdateAPIKey(ctx context.Context, ref, id string, in UpdateAPIKey) (*APIKey, error) {
+	key := &APIKey{}
+	if err := c.do(ctx, http.MethodPatch, projectPath(ref, "api-keys", id)+"?reveal=true", in, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// DeleteAPIKey deletes an API key of a project.
+func (c *Client) DeleteAPIKey(ctx context.Context, ref, id string) error {
+	return c.do(ctx, http.MethodDelete, projectPath(ref, "api-keys", id), nil, nil)
+}
@@ -0,0 +1,107 @@
+package management
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// defaultTimeout bounds every request to the Management API
+const defaultTimeout = 30 * time.Second
+
+// Client is a minimal client of the Supabase Management API.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// New returns a client for the Management API at baseURL authenticated with token.
+func New(baseURL, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// NewFromConfig returns a client configured from the Supabase section of the config.
+func NewFromConfig(conf *config.Config) *Client {
+	return New(conf.Supabase.APIURL, conf.Supabase.AccessToken)
+}
+
+// do sends a request and decodes a JSON response into out when out is not nil.
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return errors.Wrapf(err, errors.ErrorInvalidArgument, "encode %s %s", method, path)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return errors.Wrapf(err, errors.ErrorInvalidArgument, "build %s %s", method, path)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, errors.ErrorUnknown, "%s %s", method, path)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf(kindForStatus(resp.StatusCode), "%s %s: %s %s",
+			method, path, resp.Status, strings.TrimSpace(string(message)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.Wrapf(err, errors.ErrorUnknown, "decode %s %s", method, path)
+	}
+	return nil
+}
+
+func kindForStatus(status int) errors.Kind {
+	switch status {
+	case http.StatusNotFound:
+		return errors.ErrorNotFound
+	case http.StatusConflict:
+		return errors.ErrorAlreadyExists
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return errors.ErrorInvalidArgument
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return errors.ErrorPermissionDenied
+	case http.StatusTooManyRequests:
+		return errors.ErrorResourceExhausted
+	default:
+		return errors.ErrorUnknown
+	}
+}
+
+func projectPath(ref string, parts ...string) string {
+	path := fmt.Sprintf("/v1/projects/%s", ref)
+	for _, part := range parts {
+		path += "/" + part
+	}
+	return path
+}
@@ -0,0 +1,174 @@
+package management_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/mockapi"
+)
+
+func TestClientProjects(t *testing.T) {
+	t.Parallel()
+
+	server := mockapi.New()
+	defer server.Close()
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	server.AddProject(management.Project{
+		ID:             "abcdefghijklmnopqrst",
+		OrganizationID: "hadenlabs",
+		Name:           "tftest-api-1",
+		Region:         "us-east-1",
+		Status:         "ACTIVE_HEALTHY",
+		CreatedAt:      created,
+	})
+
+	client := server.Client()
+	ctx := context.Background()
+
+	projects, err := client.ListProjects(ctx)
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+	assert.Equal(t, "tftest-api-1", projects[0].Name)
+	assert.True(t, created.Equal(projects[0].CreatedAt))
+
+	project, err := client.GetProject(ctx, "abcdefghijklmnopqrst")
+	require.NoError(t, err)
+	assert.Equal(t, "hadenlabs", project.OrganizationID)
+
+	require.NoError(t, client.DeleteProject(ctx, "abcdefghijklmnopqrst"))
+	assert.Empty(t, server.Projects())
+
+	_, err = client.GetProject(ctx, "abcdefghijklmnopqrst")
+	assert.True(t, errors.IsKind(err, errors.ErrorNotFound))
+
+	project, err = client.CreateProject(ctx, management.CreateProject{
+		OrganizationID: "hadenlabs",
+		Name:           "tftest-api-2",
+		DBPass:         "SecurePassword123!",
+		Region:         "eu-west-1",
+	})
+	require.NoError(t, err)
+	assert.Len(t, project.ID, 20)
+	assert.Equal(t, "eu-west-1", project.Region)
+	keys, err := client.ListAPIKeys(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	_, err = client.CreateProject(ctx, management.CreateProject{Name: "tftest-api-3"})
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+}
+
+func TestClientOrganizations(t *testing.T) {
+	t.Parallel()
+
+	server := mockapi.New()
+	defer server.Close()
+	server.AddOrganization(management.Organization{ID: "hadenlabs", Name: "Haden Labs", Plan: management.PlanFree})
+
+	client := server.Client()
+	ctx := context.Background()
+
+	organization, err := client.GetOrganization(ctx, "hadenlabs")
+	require.NoError(t, err)
+	assert.Equal(t, management.PlanFree, organization.Plan)
+
+	_, err = client.GetOrganization(ctx, "acme")
+	assert.True(t, errors.IsKind(err, errors.ErrorNotFound))
+}
+
+func TestClientUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	server := mockapi.New()
+	defer server.Close()
+
+	_, err := management.New(server.URL, "wrong").ListProjects(context.Background())
+	assert.True(t, errors.IsKind(err, errors.ErrorPermissionDenied), "%v", err)
+}
+
+func TestClientStatusKinds(t *testing.T) {
+	t.Parallel()
+
+	cases := map[int]errors.Kind{
+		http.StatusBadRequest:          errors.ErrorInvalidArgument,
+		http.StatusUnauthorized:        errors.ErrorPermissionDenied,
+		http.StatusForbidden:           errors.ErrorPermissionDenied,
+		http.StatusNotFound:            errors.ErrorNotFound,
+		http.StatusConflict:            errors.ErrorAlreadyExists,
+		http.StatusTooManyRequests:     errors.ErrorResourceExhausted,
+		http.StatusInternalServerError: errors.ErrorUnknown,
+	}
+	for status, kind := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(status)
+		}))
+		_, err := management.New(server.URL, "sbp_test").ListProjects(context.Background())
+		server.Close()
+		assert.True(t, errors.IsKind(err, kind), "%d: %v", status, err)
+	}
+}
+
+func TestClientAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	server := mockapi.New()
+	defer server.Close()
+
+	ref := faker.Project().Ref()
+	server.AddProject(management.Project{ID: ref, OrganizationID: "hadenlabs", Name: "tftest-keys"})
+
+	client := server.Client()
+	ctx := context.Background()
+
+	keys, err := client.ListAPIKeys(ctx, ref)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	for _, key := range keys {
+		assert.Equal(t, management.APIKeyTypeLegacy, key.Type)
+		_, err := faker.VerifyLegacyJWT(key.APIKey, faker.TestJWTSecret, key.Name, ref)
+		assert.NoError(t, err, key.Name)
+	}
+
+	name := faker.ApiKey().SecretName()
+	created, err := client.CreateAPIKey(ctx, ref, management.CreateAPIKey{
+		Type:              management.APIKeyTypeSecret,
+		Name:              name,
+		SecretJWTTemplate: &management.SecretJWTTemplate{Role: faker.RoleServiceRole},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, faker.VerifyAPIKey(created.APIKey, faker.APIKeyTypeSecret))
+
+	got, err := client.GetAPIKey(ctx, ref, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created, got)
+
+	_, err = client.CreateAPIKey(ctx, ref, management.CreateAPIKey{Type: management.APIKeyTypeSecret, Name: name})
+	assert.True(t, errors.IsKind(err, errors.ErrorAlreadyExists))
+
+	_, err = client.CreateAPIKey(ctx, ref, management.CreateAPIKey{Type: management.APIKeyTypeSecret, Name: "Not Valid"})
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+
+	renamed := faker.ApiKey().SecretName()
+	updated, err := client.UpdateAPIKey(ctx, ref, created.ID, management.UpdateAPIKey{Name: &renamed})
+	require.NoError(t, err)
+	assert.Equal(t, renamed, updated.Name)
+	assert.Equal(t, created.APIKey, updated.APIKey)
+
+	_, err = client.UpdateAPIKey(ctx, ref, keys[0].ID, management.UpdateAPIKey{Name: &name})
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument), "legacy keys are read-only")
+
+	require.NoError(t, client.DeleteAPIKey(ctx, ref, created.ID))
+	_, err = client.GetAPIKey(ctx, ref, created.ID)
+	assert.True(t, errors.IsKind(err, errors.ErrorNotFound))
+	assert.True(t, errors.IsKind(client.DeleteAPIKey(ctx, ref, created.ID), errors.ErrorNotFound))
+}
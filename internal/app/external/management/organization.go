@@ -0,0 +1,32 @@
+package management
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Organization plans returned by the Management API
+const (
+	PlanFree       = "free"
+	PlanPro        = "pro"
+	PlanTeam       = "team"
+	PlanEnterprise = "enterprise"
+)
+
+// Organization is an organization as returned by the Management API.
+type Organization struct {
+	// ID is the organization slug
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Plan string `json:"plan"`
+}
+
+// GetOrganization returns a single organization, including its plan.
+func (c *Client) GetOrganization(ctx context.Context, slug string) (*Organization, error) {
+	organization := &Organization{}
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/organizations/%s", slug), nil, organization); err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
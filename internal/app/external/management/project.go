@@ -0,0 +1,59 @@
+package management
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Project is a project as returned by the Management API.
+type Project struct {
+	// ID is the project reference
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	Name           string    `json:"name"`
+	Region         string    `json:"region"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// CreateProject is the body of a project creation request.
+type CreateProject struct {
+	OrganizationID      string `json:"organization_id"`
+	Name                string `json:"name"`
+	DBPass              string `json:"db_pass"`
+	Region              string `json:"region"`
+	DesiredInstanceSize string `json:"desired_instance_size,omitempty"`
+}
+
+// ListProjects returns every project the access token can see.
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	projects := []Project{}
+	if err := c.do(ctx, http.MethodGet, "/v1/projects", nil, &projects); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// GetProject returns a single project.
+func (c *Client) GetProject(ctx context.Context, ref string) (*Project, error) {
+	project := &Project{}
+	if err := c.do(ctx, http.MethodGet, projectPath(ref), nil, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// CreateProject creates a project in an organization.
+func (c *Client) CreateProject(ctx context.Context, in CreateProject) (*Project, error) {
+	project := &Project{}
+	if err := c.do(ctx, http.MethodPost, "/v1/projects", in, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// DeleteProject deletes a project and all its resources.
+func (c *Client) DeleteProject(ctx context.Context, ref string) error {
+	return c.do(ctx, http.MethodDelete, projectPath(ref), nil, nil)
+}
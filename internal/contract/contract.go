@@ -0,0 +1,186 @@
+package contract
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Level is the semver component a change requires to be released under.
+type Level int
+
+// Change levels, ordered from least to most disruptive.
+const (
+	LevelPatch Level = iota
+	LevelMinor
+	LevelMajor
+)
+
+// String returns the semver name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelMajor:
+		return "major"
+	case LevelMinor:
+		return "minor"
+	default:
+		return "patch"
+	}
+}
+
+// Schema is the public interface of a module: the variables it accepts and the outputs it publishes.
+type Schema struct {
+	Module    string     `json:"module"`
+	Variables []Variable `json:"variables"`
+	Outputs   []Output   `json:"outputs"`
+}
+
+// Variable is the contract of a single input variable.
+type Variable struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Required  bool   `json:"required"`
+	Sensitive bool   `json:"sensitive"`
+}
+
+// Output is the contract of a single output.
+type Output struct {
+	Name      string `json:"name"`
+	Sensitive bool   `json:"sensitive"`
+}
+
+// Change is a single difference between two schemas.
+type Change struct {
+	Level   Level
+	Kind    string
+	Name    string
+	Message string
+}
+
+// String formats the change as a diff line.
+func (c Change) String() string {
+	return fmt.Sprintf("[%s] %s %s: %s", c.Level, c.Kind, c.Name, c.Message)
+}
+
+// Diff lists every change between a checked-in schema and the current module.
+type Diff struct {
+	Module  string
+	Changes []Change
+}
+
+// Level returns the highest level among the changes.
+func (d Diff) Level() Level {
+	level := LevelPatch
+	for _, change := range d.Changes {
+		if change.Level > level {
+			level = change.Level
+		}
+	}
+	return level
+}
+
+// Breaking returns the changes that require a major release.
+func (d Diff) Breaking() []Change {
+	breaking := []Change{}
+	for _, change := range d.Changes {
+		if change.Level == LevelMajor {
+			breaking = append(breaking, change)
+		}
+	}
+	return breaking
+}
+
+// Empty reports whether the module still matches its schema.
+func (d Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// String renders the diff as a report headed by the required release level.
+func (d Diff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "module %s: %s\n", d.Module, d.Level())
+	for _, change := range d.Changes {
+		fmt.Fprintf(&b, "  %s\n", change)
+	}
+	return b.String()
+}
+
+// Compare returns the changes needed to go from the previous schema to the current one.
+func Compare(previous, current *Schema) Diff {
+	diff := Diff{Module: current.Module}
+	diff.Changes = append(diff.Changes, compareVariables(previous.Variables, current.Variables)...)
+	diff.Changes = append(diff.Changes, compareOutputs(previous.Outputs, current.Outputs)...)
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		return diff.Changes[i].Level > diff.Changes[j].Level
+	})
+	return diff
+}
+
+func compareVariables(previous, current []Variable) []Change {
+	changes := []Change{}
+	currentByName := map[string]Variable{}
+	for _, variable := range current {
+		currentByName[variable.Name] = variable
+	}
+	previousByName := map[string]Variable{}
+	for _, old := range previous {
+		previousByName[old.Name] = old
+		variable, ok := currentByName[old.Name]
+		if !ok {
+			changes = append(changes, Change{LevelMajor, "variable", old.Name, "removed"})
+			continue
+		}
+		if old.Type != variable.Type {
+			changes = append(changes, Change{LevelMajor, "variable", old.Name,
+				fmt.Sprintf("type changed from %s to %s", old.Type, variable.Type)})
+		}
+		if !old.Required && variable.Required {
+			changes = append(changes, Change{LevelMajor, "variable", old.Name, "became required"})
+		}
+		if old.Required && !variable.Required {
+			changes = append(changes, Change{LevelMinor, "variable", old.Name, "became optional"})
+		}
+		if old.Sensitive != variable.Sensitive {
+			changes = append(changes, Change{LevelMajor, "variable", old.Name,
+				fmt.Sprintf("sensitive changed from %t to %t", old.Sensitive, variable.Sensitive)})
+		}
+	}
+	for _, variable := range current {
+		if _, ok := previousByName[variable.Name]; ok {
+			continue
+		}
+		if variable.Required {
+			changes = append(changes, Change{LevelMajor, "variable", variable.Name, "added as required"})
+			continue
+		}
+		changes = append(changes, Change{LevelMinor, "variable", variable.Name, "added as optional"})
+	}
+	return changes
+}
+
+func compareOutputs(previous, current []Output) []Change {
+	changes := []Change{}
+	currentByName := map[string]Output{}
+	for _, output := range current {
+		currentByName[output.Name] = output
+	}
+	previousByName := map[string]Output{}
+	for _, old := range previous {
+		previousByName[old.Name] = old
+		output, ok := currentByName[old.Name]
+		if !ok {
+			changes = append(changes, Change{LevelMajor, "output", old.Name, "removed"})
+			continue
+		}
+		if old.Sensitive != output.Sensitive {
+			changes = append(changes, Change{LevelMajor, "output", old.Name,
+				fmt.Sprintf("sensitive changed from %t to %t", old.Sensitive, output.Sensitive)})
+		}
+	}
+	for _, output := range current {
+		if _, ok := previousByName[output.Name]; !ok {
+			changes = append(changes, Change{LevelMinor, "output", output.Name, "added"})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,118 @@
+package contract
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/golden"
+)
+
+func TestModulesMatchSchema(t *testing.T) {
+	discovered, err := modules.Discover(modules.MustRoot())
+	require.NoError(t, err)
+
+	for _, module := range discovered {
+		t.Run(module.Name, func(t *testing.T) {
+			current := FromModule(module)
+
+			if golden.Update() {
+				data, err := Marshal(current)
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(filepath.Join(SchemaDir, module.Name+".json"), data, 0o644))
+				return
+			}
+
+			previous, err := Read(module.Name)
+			require.NoError(t, err, "run `UPDATE_GOLDEN=true go test ./internal/contract` to record the schema")
+
+			diff := Compare(previous, current)
+			assert.Empty(t, diff.Breaking(), diff.String())
+			assert.True(t, diff.Empty(), "module interface changed, update the schema:\n%s", diff)
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	previous := &Schema{
+		Module: "apikey",
+		Variables: []Variable{
+			{Name: "name", Type: "string", Required: true},
+			{Name: "description", Type: "string"},
+			{Name: "legacy", Type: "bool"},
+		},
+		Outputs: []Output{
+			{Name: "id"},
+			{Name: "api_key", Sensitive: true},
+			{Name: "type"},
+		},
+	}
+	current := &Schema{
+		Module: "apikey",
+		Variables: []Variable{
+			{Name: "name", Type: "string"},
+			{Name: "description", Type: "number"},
+			{Name: "project_id", Type: "string", Required: true},
+			{Name: "tags", Type: "list(string)"},
+		},
+		Outputs: []Output{
+			{Name: "id"},
+			{Name: "api_key"},
+			{Name: "apikey"},
+		},
+	}
+
+	diff := Compare(previous, current)
+
+	assert.Equal(t, LevelMajor, diff.Level())
+	assert.ElementsMatch(t, []Change{
+		{LevelMajor, "variable", "legacy", "removed"},
+		{LevelMajor, "variable", "description", "type changed from string to number"},
+		{LevelMajor, "variable", "project_id", "added as required"},
+		{LevelMajor, "output", "type", "removed"},
+		{LevelMajor, "output", "api_key", "sensitive changed from true to false"},
+	}, diff.Breaking())
+	assert.Contains(t, diff.Changes, Change{LevelMinor, "variable", "name", "became optional"})
+	assert.Contains(t, diff.Changes, Change{LevelMinor, "variable", "tags", "added as optional"})
+	assert.Contains(t, diff.Changes, Change{LevelMinor, "output", "apikey", "added"})
+	assert.Contains(t, diff.String(), "module apikey: major")
+}
+
+func TestCompare_Additive(t *testing.T) {
+	t.Parallel()
+
+	previous := &Schema{Module: "project", Outputs: []Output{{Name: "id"}}}
+	current := &Schema{Module: "project", Outputs: []Output{{Name: "id"}, {Name: "region"}}}
+
+	diff := Compare(previous, current)
+	assert.Equal(t, LevelMinor, diff.Level())
+	assert.Empty(t, diff.Breaking())
+}
+
+func TestCompare_Unchanged(t *testing.T) {
+	t.Parallel()
+
+	schema := &Schema{Module: "project", Variables: []Variable{{Name: "name", Type: "string", Required: true}}}
+
+	diff := Compare(schema, schema)
+	assert.True(t, diff.Empty())
+	assert.Equal(t, LevelPatch, diff.Level())
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	schema, err := Load(filepath.Join(modules.MustRoot(), modules.ModulesDir, "project"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "project", schema.Module)
+	assert.Contains(t, schema.Variables, Variable{Name: "database_password", Type: "string", Required: true, Sensitive: true})
+	assert.Contains(t, schema.Variables, Variable{Name: "region", Type: "string"})
+	assert.Contains(t, schema.Outputs, Output{Name: "module_enabled"})
+}
@@ -0,0 +1,39 @@
+package contract
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+// Load parses the module in dir into a Schema.
+func Load(dir string) (*Schema, error) {
+	module, err := modules.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	return FromModule(module), nil
+}
+
+// FromModule extracts the public interface of a parsed module.
+func FromModule(module *modules.Module) *Schema {
+	schema := &Schema{Module: module.Name}
+	for _, variable := range module.Variables {
+		schema.Variables = append(schema.Variables, Variable{
+			Name:      variable.Name,
+			Type:      strings.ReplaceAll(variable.Type, " ", ""),
+			Required:  variable.Required(),
+			Sensitive: variable.Sensitive,
+		})
+	}
+	for _, output := range module.Outputs {
+		schema.Outputs = append(schema.Outputs, Output{
+			Name:      output.Name,
+			Sensitive: output.Sensitive,
+		})
+	}
+	sort.Slice(schema.Variables, func(i, j int) bool { return schema.Variables[i].Name < schema.Variables[j].Name })
+	sort.Slice(schema.Outputs, func(i, j int) bool { return schema.Outputs[i].Name < schema.Outputs[j].Name })
+	return schema
+}
@@ -0,0 +1,53 @@
+package contract
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// SchemaDir is the directory, relative to this package, holding the checked-in schemas.
+const SchemaDir = "schema"
+
+//go:embed schema/*.json
+var schemas embed.FS
+
+// Modules returns the names of the modules with a checked-in schema.
+func Modules() []string {
+	entries, err := schemas.ReadDir(SchemaDir)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Read returns the checked-in schema of a module.
+func Read(module string) (*Schema, error) {
+	data, err := schemas.ReadFile(path.Join(SchemaDir, module+".json"))
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.ErrorNotFound, "schema for module %s", module)
+	}
+	schema := &Schema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, errors.Wrapf(err, errors.ErrorInvalidArgument, "decode schema for module %s", module)
+	}
+	return schema, nil
+}
+
+// Marshal encodes a schema in the checked-in format.
+func Marshal(schema *Schema) ([]byte, error) {
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorUnknown, "encode schema")
+	}
+	return append(data, '\n'), nil
+}
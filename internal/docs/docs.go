@@ -0,0 +1,193 @@
+package docs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+const (
+	inputsHeading  = "## Inputs"
+	outputsHeading = "## Outputs"
+
+	// endMarker closes the block terraform-docs writes, which leaves the Inputs and Outputs sections to Update
+	endMarker = "<!-- END_TF_DOCS -->"
+)
+
+// Files returns the documents of a module that carry Inputs and Outputs sections.
+func Files(module *modules.Module) []string {
+	return []string{
+		filepath.Join(module.Dir, "README.md"),
+		filepath.Join(module.Dir, "docs", "include", "terraform.md"),
+	}
+}
+
+// Render returns the Inputs and Outputs sections of a module.
+func Render(module *modules.Module) string {
+	var b strings.Builder
+	b.WriteString(inputsHeading + "\n\n")
+	b.WriteString(RenderInputs(module))
+	b.WriteString("\n" + outputsHeading + "\n\n")
+	b.WriteString(RenderOutputs(module))
+	return b.String()
+}
+
+// RenderInputs returns the inputs table of a module.
+func RenderInputs(module *modules.Module) string {
+	variables := append([]modules.Variable{}, module.Variables...)
+	if len(variables) == 0 {
+		return "No inputs.\n"
+	}
+	sort.Slice(variables, func(i, j int) bool { return variables[i].Name < variables[j].Name })
+
+	t := table{
+		header: []string{"Name", "Description", "Type", "Default", "Required", "Sensitive"},
+		center: []bool{false, false, false, false, true, true},
+	}
+	for _, variable := range variables {
+		t.rows = append(t.rows, []string{
+			variable.Name,
+			cell(variable.Description),
+			code(variable.Type),
+			defaultValue(variable),
+			yesNo(variable.Required()),
+			yesNo(variable.Sensitive),
+		})
+	}
+	return t.String()
+}
+
+// RenderOutputs returns the outputs table of a module.
+func RenderOutputs(module *modules.Module) string {
+	outputs := append([]modules.Output{}, module.Outputs...)
+	if len(outputs) == 0 {
+		return "No outputs.\n"
+	}
+	sort.Slice(outputs, func(i, j int) bool { return outputs[i].Name < outputs[j].Name })
+
+	t := table{
+		header: []string{"Name", "Description", "Sensitive"},
+		center: []bool{false, false, true},
+	}
+	for _, output := range outputs {
+		t.rows = append(t.rows, []string{output.Name, cell(output.Description), yesNo(output.Sensitive)})
+	}
+	return t.String()
+}
+
+// Update replaces the Inputs and Outputs sections of content with freshly rendered ones. Content
+// terraform-docs just rewrote has none, they are added at the end of its block.
+func Update(content string, module *modules.Module) (string, error) {
+	lines := strings.SplitAfter(content, "\n")
+	start, end := -1, -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == inputsHeading {
+			start = i
+		}
+		if start >= 0 && strings.TrimSpace(line) == outputsHeading {
+			end = sectionEnd(lines, i)
+			break
+		}
+	}
+	if start < 0 && end < 0 {
+		for i, line := range lines {
+			if strings.TrimSpace(line) != endMarker {
+				continue
+			}
+			section := Render(module)
+			if i > 0 && strings.TrimSpace(lines[i-1]) != "" {
+				section = "\n" + section
+			}
+			return strings.Join(lines[:i], "") + section + strings.Join(lines[i:], ""), nil
+		}
+	}
+	if start < 0 || end < 0 {
+		return "", errors.Errorf(errors.ErrorNotFound, "no %q followed by %q section", inputsHeading, outputsHeading)
+	}
+	return strings.Join(lines[:start], "") + Render(module) + strings.Join(lines[end:], ""), nil
+}
+
+// Generate rewrites the Inputs and Outputs sections of every module document under root.
+// With check set nothing is written. It returns the documents that were, or would be, changed.
+func Generate(root string, check bool) ([]string, error) {
+	discovered, err := modules.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	stale := []string{}
+	for _, module := range discovered {
+		for _, file := range Files(module) {
+			data, err := os.ReadFile(file)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, errors.ErrorUnknown, "read %s", file)
+			}
+			updated, err := Update(string(data), module)
+			if err != nil {
+				return nil, errors.Wrapf(err, errors.ErrorInvalidArgument, "update %s", file)
+			}
+			if updated == string(data) {
+				continue
+			}
+			stale = append(stale, file)
+			if check {
+				continue
+			}
+			if err := os.WriteFile(file, []byte(updated), 0o644); err != nil { //nolint:gosec
+				return nil, errors.Wrapf(err, errors.ErrorUnknown, "write %s", file)
+			}
+		}
+	}
+	return stale, nil
+}
+
+// sectionEnd returns the index of the line following the body of the section headed at heading.
+func sectionEnd(lines []string, heading int) int {
+	i := heading + 1
+	for i < len(lines) && strings.TrimSpace(lines[i]) == "" {
+		i++
+	}
+	for i < len(lines) && strings.TrimSpace(lines[i]) != "" && !strings.HasPrefix(lines[i], "#") &&
+		!strings.HasPrefix(lines[i], "<!--") {
+		i++
+	}
+	return i
+}
+
+func defaultValue(variable modules.Variable) string {
+	if variable.Required() {
+		return "n/a"
+	}
+	data, err := json.Marshal(variable.Default)
+	if err != nil {
+		return "n/a"
+	}
+	return code(string(data))
+}
+
+func code(s string) string {
+	if s == "" {
+		return "n/a"
+	}
+	return "`" + s + "`"
+}
+
+func cell(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", "<br/>")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,133 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+const moduleHCL = `
+variable "name" {
+  type        = string
+  description = "(Required) Name of the key"
+}
+
+variable "password" {
+  type        = string
+  description = "(Required) Password | secret"
+  sensitive   = true
+}
+
+variable "enabled" {
+  type        = bool
+  description = <<-EOT
+    (Optional) Whether to create resources.
+    Defaults to true.
+  EOT
+  default     = true
+}
+
+output "id" {
+  description = "Identifier"
+  value       = "id"
+}
+
+output "password" {
+  description = "Password"
+  value       = var.password
+  sensitive   = true
+}
+`
+
+func loadModule(t *testing.T) *modules.Module {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(moduleHCL), 0o600))
+	module, err := modules.Load(dir)
+	require.NoError(t, err)
+	return module
+}
+
+func TestRenderInputs(t *testing.T) {
+	t.Parallel()
+
+	expected := "| Name     | Description                                                   | Type     | Default | Required | Sensitive |\n" +
+		"| -------- | ------------------------------------------------------------- | -------- | ------- | :------: | :-------: |\n" +
+		"| enabled  | (Optional) Whether to create resources.<br/>Defaults to true. | `bool`   | `true`  |    no    |    no     |\n" +
+		"| name     | (Required) Name of the key                                    | `string` | n/a     |   yes    |    no     |\n" +
+		"| password | (Required) Password \\| secret                                 | `string` | n/a     |   yes    |    yes    |\n"
+	assert.Equal(t, expected, RenderInputs(loadModule(t)))
+}
+
+func TestRenderInputs_Compact(t *testing.T) {
+	t.Parallel()
+
+	root := modules.MustRoot()
+	project, err := modules.Find(root, "project")
+	require.NoError(t, err)
+
+	inputs := RenderInputs(project)
+	assert.Contains(t, inputs, "| --- | --- | --- | --- | :-: | :-: |\n")
+	assert.Contains(t, inputs, "| database_password | (Required, Sensitive) Password for the project database | `string` | n/a | yes | yes |\n")
+}
+
+func TestRenderOutputs(t *testing.T) {
+	t.Parallel()
+
+	expected := "| Name     | Description | Sensitive |\n" +
+		"| -------- | ----------- | :-------: |\n" +
+		"| id       | Identifier  |    no     |\n" +
+		"| password | Password    |    yes    |\n"
+	assert.Equal(t, expected, RenderOutputs(loadModule(t)))
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	module := loadModule(t)
+	content := "# Module\n\n## Inputs\n\n| stale |\n\n## Outputs\n\n| stale |\n| stale |\n\n## Examples\n\nbody\n"
+
+	updated, err := Update(content, module)
+	require.NoError(t, err)
+
+	assert.Equal(t, "# Module\n\n"+Render(module)+"\n## Examples\n\nbody\n", updated)
+
+	again, err := Update(updated, module)
+	require.NoError(t, err)
+	assert.Equal(t, updated, again, "Update should be idempotent")
+}
+
+func TestUpdate_TerraformDocs(t *testing.T) {
+	t.Parallel()
+
+	module := loadModule(t)
+	content := "<!-- BEGIN_TF_DOCS -->\n## Resources\n\nNo resources.\n<!-- END_TF_DOCS -->\n"
+
+	updated, err := Update(content, module)
+	require.NoError(t, err)
+	assert.Equal(t, "<!-- BEGIN_TF_DOCS -->\n## Resources\n\nNo resources.\n\n"+Render(module)+"<!-- END_TF_DOCS -->\n", updated)
+
+	again, err := Update(updated, module)
+	require.NoError(t, err)
+	assert.Equal(t, updated, again, "the sections added are then replaced")
+}
+
+func TestUpdate_MissingSections(t *testing.T) {
+	t.Parallel()
+
+	_, err := Update("# Module\n\nNo tables here.\n", loadModule(t))
+	assert.Error(t, err)
+}
+
+func TestModuleDocsUpToDate(t *testing.T) {
+	t.Parallel()
+
+	stale, err := Generate(modules.MustRoot(), true)
+	require.NoError(t, err)
+	assert.Empty(t, stale, "run `go run ./cmd/readme` to regenerate module documentation")
+}
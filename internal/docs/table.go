@@ -0,0 +1,85 @@
+package docs
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// printWidth matches the prettier setting for Markdown; wider tables are written compact.
+const printWidth = 120
+
+// table renders Markdown tables the way prettier formats them, so generated
+// sections survive the pre-commit hooks unchanged.
+type table struct {
+	header []string
+	center []bool
+	rows   [][]string
+}
+
+// String renders the table, aligned when it fits in printWidth and compact otherwise.
+func (t table) String() string {
+	widths := make([]int, len(t.header))
+	for i, h := range t.header {
+		widths[i] = max(utf8.RuneCountInString(h), 3)
+	}
+	for _, row := range t.rows {
+		for i, c := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c))
+		}
+	}
+
+	aligned := t.render(widths)
+	for _, line := range strings.Split(aligned, "\n") {
+		if utf8.RuneCountInString(line) > printWidth {
+			return t.render(nil)
+		}
+	}
+	return aligned
+}
+
+func (t table) render(widths []int) string {
+	var b strings.Builder
+	t.line(&b, t.header, widths)
+
+	separator := make([]string, len(t.header))
+	for i := range t.header {
+		width := 3
+		if widths != nil {
+			width = widths[i]
+		}
+		if t.center[i] {
+			separator[i] = ":" + strings.Repeat("-", width-2) + ":"
+		} else {
+			separator[i] = strings.Repeat("-", width)
+		}
+	}
+	t.line(&b, separator, nil)
+
+	for _, row := range t.rows {
+		t.line(&b, row, widths)
+	}
+	return b.String()
+}
+
+func (t table) line(b *strings.Builder, cells []string, widths []int) {
+	b.WriteString("|")
+	for i, c := range cells {
+		if widths != nil {
+			c = pad(c, widths[i], t.center[i])
+		}
+		b.WriteString(" " + c + " |")
+	}
+	b.WriteString("\n")
+}
+
+func pad(s string, width int, center bool) string {
+	gap := width - utf8.RuneCountInString(s)
+	if gap <= 0 {
+		return s
+	}
+	if !center {
+		return s + strings.Repeat(" ", gap)
+	}
+	left := gap / 2
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", gap-left)
+}
@@ -13,15 +13,17 @@ type Kind string
 
 // Error kinds.
 const (
-	ErrorReadConfig       Kind = "config read error"
-	ErrorParseConfig      Kind = "config parse error"
-	ErrorNotImplemented   Kind = "not implement"
-	ErrorCanceled         Kind = "canceled"
-	ErrorUnknown          Kind = "unknown error"
-	ErrorInvalidArgument  Kind = "invalid argument"
-	ErrorDeadlineExceeded Kind = "deadline exceeded"
-	ErrorNotFound         Kind = "entity not found"
-	ErrorAlreadyExists    Kind = "already exists"
+	ErrorReadConfig        Kind = "config read error"
+	ErrorParseConfig       Kind = "config parse error"
+	ErrorNotImplemented    Kind = "not implement"
+	ErrorCanceled          Kind = "canceled"
+	ErrorUnknown           Kind = "unknown error"
+	ErrorInvalidArgument   Kind = "invalid argument"
+	ErrorDeadlineExceeded  Kind = "deadline exceeded"
+	ErrorNotFound          Kind = "entity not found"
+	ErrorAlreadyExists     Kind = "already exists"
+	ErrorPermissionDenied  Kind = "permission denied"
+	ErrorResourceExhausted Kind = "resource exhausted"
 )
 
 // FieldViolation is a struct for providing field error details in HTTP error. It matches the same struct in errdetails package.
@@ -0,0 +1,144 @@
+package fixture
+
+import (
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
+)
+
+// APIKey provides a simple structure for testing the apikey module
+// Its faker tags let faker.FakeData fill every field
+type APIKey struct {
+	// ProjectID is the reference of the project owning the key
+	ProjectID string `faker:"ProjectRefFaker"`
+
+	// Name is the API key name
+	Name string `faker:"ApiKeyNameFaker"`
+
+	// Description is the API key description
+	Description string `faker:"ApiKeyDescriptionFaker"`
+}
+
+// NewAPIKey creates a new APIKey instance with values from faker
+func NewAPIKey() *APIKey {
+	fake := faker.ApiKey()
+
+	return &APIKey{
+		ProjectID:   faker.Project().Ref(),
+		Name:        fake.Name(),
+		Description: fake.Description(),
+	}
+}
+
+// WithProjectID sets a custom project reference and returns a new APIKey instance
+func (a *APIKey) WithProjectID(projectID string) *APIKey {
+	return &APIKey{
+		ProjectID:   projectID,
+		Name:        a.Name,
+		Description: a.Description,
+	}
+}
+
+// WithName sets a custom API key name and returns a new APIKey instance
+func (a *APIKey) WithName(name string) *APIKey {
+	return &APIKey{
+		ProjectID:   a.ProjectID,
+		Name:        name,
+		Description: a.Description,
+	}
+}
+
+// WithDescription sets a custom description and returns a new APIKey instance
+func (a *APIKey) WithDescription(description string) *APIKey {
+	return &APIKey{
+		ProjectID:   a.ProjectID,
+		Name:        a.Name,
+		Description: description,
+	}
+}
+
+// ToMap converts APIKey to a map matching modules/apikey/variables.tf
+func (a *APIKey) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"project_id":     a.ProjectID,
+		"name":           a.Name,
+		"description":    a.Description,
+		"module_enabled": true, // Default value
+	}
+}
+
+// ToTFVars renders the API key as a terraform.tfvars file
+func (a *APIKey) ToTFVars() ([]byte, error) {
+	return tfvars.Encode(a.ToMap())
+}
+
+// ToTFVarsJSON renders the API key as a .tfvars.json file
+func (a *APIKey) ToTFVarsJSON() ([]byte, error) {
+	return tfvars.EncodeJSON(a.ToMap())
+}
+
+// ProjectWithAPIKeys is a test stack of a project and the API keys created in it
+// The keys' ProjectID is left to Terraform, which wires it from the project module output
+type ProjectWithAPIKeys struct {
+	// Project is the project holding the keys
+	Project *Project
+
+	// APIKeys are the keys created in the project
+	APIKeys []*APIKey
+}
+
+// NewProjectWithAPIKeys creates a stack of a default project and n API keys
+func NewProjectWithAPIKeys(n int) *ProjectWithAPIKeys {
+	keys := make([]*APIKey, 0, n)
+	for i := 0; i < n; i++ {
+		keys = append(keys, NewAPIKey().WithProjectID(""))
+	}
+	return &ProjectWithAPIKeys{
+		Project: NewProject(),
+		APIKeys: keys,
+	}
+}
+
+// WithProject sets a custom project and returns a new ProjectWithAPIKeys instance
+func (s *ProjectWithAPIKeys) WithProject(project *Project) *ProjectWithAPIKeys {
+	return &ProjectWithAPIKeys{
+		Project: project,
+		APIKeys: append([]*APIKey{}, s.APIKeys...),
+	}
+}
+
+// WithAPIKeys replaces the API keys and returns a new ProjectWithAPIKeys instance
+func (s *ProjectWithAPIKeys) WithAPIKeys(keys ...*APIKey) *ProjectWithAPIKeys {
+	return &ProjectWithAPIKeys{
+		Project: s.Project,
+		APIKeys: append([]*APIKey{}, keys...),
+	}
+}
+
+// AddAPIKey appends an API key and returns a new ProjectWithAPIKeys instance
+func (s *ProjectWithAPIKeys) AddAPIKey(key *APIKey) *ProjectWithAPIKeys {
+	return s.WithAPIKeys(append(append([]*APIKey{}, s.APIKeys...), key)...)
+}
+
+// ToMap converts the stack to the variables of the apikey-multiple test fixture
+func (s *ProjectWithAPIKeys) ToMap() map[string]interface{} {
+	vars := s.Project.ToMap()
+	apikeys := make([]interface{}, 0, len(s.APIKeys))
+	for _, key := range s.APIKeys {
+		apikeys = append(apikeys, map[string]interface{}{
+			"name":        key.Name,
+			"description": key.Description,
+		})
+	}
+	vars["apikeys"] = apikeys
+	return vars
+}
+
+// ToTFVars renders the stack as a terraform.tfvars file
+func (s *ProjectWithAPIKeys) ToTFVars() ([]byte, error) {
+	return tfvars.Encode(s.ToMap())
+}
+
+// ToTFVarsJSON renders the stack as a .tfvars.json file
+func (s *ProjectWithAPIKeys) ToTFVarsJSON() ([]byte, error) {
+	return tfvars.EncodeJSON(s.ToMap())
+}
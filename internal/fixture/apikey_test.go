@@ -0,0 +1,110 @@
+package fixture
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	t.Parallel()
+
+	key := NewAPIKey()
+
+	assert.Len(t, key.ProjectID, 20, "ProjectID should be a project reference")
+	assert.True(t, faker.ValidAPIKeyName(key.Name), key.Name)
+	assert.NotEmpty(t, key.Description)
+}
+
+func TestAPIKey_ImmutableWithMethods(t *testing.T) {
+	t.Parallel()
+
+	original := NewAPIKey()
+	snapshot := *original
+
+	modified := original.WithProjectID("abcdefghijklmnopqrst").WithName("ci_key").WithDescription("CI")
+
+	assert.Equal(t, snapshot, *original, "original should be unchanged")
+	assert.Equal(t, &APIKey{ProjectID: "abcdefghijklmnopqrst", Name: "ci_key", Description: "CI"}, modified)
+}
+
+func TestAPIKey_ToMapMatchesModule(t *testing.T) {
+	t.Parallel()
+
+	module, err := modules.Find(modules.MustRoot(), "apikey")
+	require.NoError(t, err)
+
+	assert.NoError(t, module.Validate(NewAPIKey().ToMap()))
+}
+
+func TestProjectWithAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	stack := NewProjectWithAPIKeys(2)
+	require.Len(t, stack.APIKeys, 2)
+
+	grown := stack.AddAPIKey(NewAPIKey().WithName("extra"))
+	assert.Len(t, stack.APIKeys, 2, "original should be unchanged")
+	assert.Len(t, grown.APIKeys, 3)
+	assert.Same(t, stack.Project, grown.Project)
+
+	project := NewProject()
+	assert.Same(t, project, stack.WithProject(project).Project)
+	assert.Empty(t, stack.WithAPIKeys().APIKeys)
+}
+
+func TestProjectWithAPIKeys_ToMapMatchesFixture(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := modules.Load(filepath.Join(modules.MustRoot(), modules.ModulesDir, "apikey", modules.FixturesDir, "apikey-multiple"))
+	require.NoError(t, err)
+
+	stack := NewProjectWithAPIKeys(3)
+	vars := stack.ToMap()
+
+	assert.NoError(t, fixture.Validate(vars))
+	assert.Len(t, vars["apikeys"], 3)
+}
+
+func TestAPIKeyToTFVars(t *testing.T) {
+	t.Parallel()
+
+	key := NewAPIKey().WithName("ci_key")
+
+	hcl, err := key.ToTFVars()
+	require.NoError(t, err)
+	assert.Contains(t, string(hcl), `name           = "ci_key"`)
+	vars, err := tfvars.Decode(hcl, "terraform.tfvars")
+	require.NoError(t, err)
+	assert.Equal(t, key.ToMap(), vars)
+
+	data, err := key.ToTFVarsJSON()
+	require.NoError(t, err)
+	vars, err = tfvars.Decode(data, "terraform.tfvars.json")
+	require.NoError(t, err)
+	assert.Equal(t, key.ToMap(), vars)
+}
+
+func TestProjectWithAPIKeysToTFVars(t *testing.T) {
+	t.Parallel()
+
+	stack := NewProjectWithAPIKeys(2)
+
+	hcl, err := stack.ToTFVars()
+	require.NoError(t, err)
+	vars, err := tfvars.Decode(hcl, "terraform.tfvars")
+	require.NoError(t, err)
+	assert.Equal(t, stack.ToMap(), vars, "nested API keys round-trip through HCL")
+
+	data, err := stack.ToTFVarsJSON()
+	require.NoError(t, err)
+	vars, err = tfvars.Decode(data, "terraform.tfvars.json")
+	require.NoError(t, err)
+	assert.Equal(t, stack.ToMap(), vars)
+}
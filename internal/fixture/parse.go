@@ -0,0 +1,62 @@
+package fixture
+
+import (
+	"fmt"
+
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
+)
+
+// ProjectModule is the module under modules/ whose variables a Project carries
+const ProjectModule = "project"
+
+// ProjectFromTFVars reads a terraform.tfvars or .tfvars.json file into a Project
+func ProjectFromTFVars(path string) (*Project, error) {
+	vars, err := tfvars.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	return ProjectFromMap(vars)
+}
+
+// ProjectFromMap builds a Project from Terraform variables
+// Defaults of the project module's variables.tf fill in missing values, and unknown,
+// missing or mistyped variables are reported as field violations of an invalid argument error
+func ProjectFromMap(vars map[string]interface{}) (*Project, error) {
+	root, err := modules.Root()
+	if err != nil {
+		return nil, err
+	}
+	module, err := modules.Find(root, ProjectModule)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := module.Defaults()
+	for k, v := range vars {
+		merged[k] = v
+	}
+	if err := module.Validate(merged); err != nil {
+		return nil, err
+	}
+
+	return &Project{
+		OrganizationID:   stringValue(merged["organization_id"]),
+		DatabasePassword: stringValue(merged["database_password"]),
+		Name:             stringValue(merged["name"]),
+		Region:           stringValue(merged["region"]),
+		InstanceSize:     stringValue(merged["instance_size"]),
+	}, nil
+}
+
+// stringValue renders a validated variable the way Terraform converts it to a string
+func stringValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,85 @@
+package fixture
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+func TestProjectFromTFVars_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	project := NewProjectWithFaker()
+
+	for name, encode := range map[string]func() ([]byte, error){
+		"terraform.tfvars":      project.ToTFVars,
+		"terraform.tfvars.json": project.ToTFVarsJSON,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := encode()
+			require.NoError(t, err)
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, os.WriteFile(path, data, 0o600))
+
+			got, err := ProjectFromTFVars(path)
+			require.NoError(t, err)
+			assert.Equal(t, project, got)
+		})
+	}
+}
+
+func TestProjectFromTFVars_Example(t *testing.T) {
+	t.Parallel()
+
+	project, err := ProjectFromTFVars(filepath.Join(modules.MustRoot(), "docs", "examples", "project.tfvars"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "tftest-example", project.Name)
+	assert.Equal(t, "eu-west-1", project.Region)
+	assert.Equal(t, "micro", project.InstanceSize, "instance_size should default from variables.tf")
+}
+
+func TestProjectFromMap_Defaults(t *testing.T) {
+	t.Parallel()
+
+	project, err := ProjectFromMap(map[string]interface{}{
+		"organization_id":   "org",
+		"name":              "tftest-defaults",
+		"database_password": "secret",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &Project{
+		OrganizationID:   "org",
+		DatabasePassword: "secret",
+		Name:             "tftest-defaults",
+		Region:           "us-east-1",
+		InstanceSize:     "micro",
+	}, project)
+}
+
+func TestProjectFromMap_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := ProjectFromMap(map[string]interface{}{
+		"organization_id": "org",
+		"name":            "tftest-invalid",
+		"project_ref":     "abc",
+	})
+	require.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+
+	ie := &errors.Error{}
+	require.True(t, errors.As(err, &ie))
+	assert.ElementsMatch(t, []errors.FieldViolation{
+		{Field: "project_ref", Description: "unknown variable"},
+		{Field: "database_password", Description: "required"},
+	}, ie.FieldViolations())
+}
@@ -1,36 +1,60 @@
-package supabase
+package fixture
 
 import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
 	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
 )
 
+// validate checks fixtures against their validate struct tags, naming fields after their json tags
+var validate = newValidate()
+
+func newValidate() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
 // Project provides a simple structure for Supabase project testing
+// Its faker tags let faker.FakeData fill every field and its validate tags drive Validate
 type Project struct {
 	// OrganizationID is the organization identifier for Supabase projects
-	OrganizationID string
+	OrganizationID string `json:"organization_id" faker:"ProjectOrganizationIDFaker" validate:"required"`
 
 	// DatabasePassword is the database password for the project
-	DatabasePassword string
+	DatabasePassword string `json:"database_password" faker:"ProjectDatabasePasswordFaker" validate:"required,min=8"`
 
 	// Name is the project name
-	Name string
+	Name string `json:"name" faker:"ProjectTestNameFaker" validate:"required,max=256"`
 
 	// Region is the AWS region for the project
-	Region string
+	Region string `json:"region" faker:"ProjectRegionFaker" validate:"required"`
 
 	// InstanceSize is the instance size for the project
-	InstanceSize string
+	InstanceSize string `json:"instance_size" faker:"ProjectInstanceSizeFaker" validate:"required,oneof=nano micro small medium large xlarge 2xlarge 4xlarge 8xlarge 12xlarge 16xlarge"`
 }
 
 // NewProject creates a new Project instance with default values
 // OrganizationID defaults to "hadenlabs", other fields use faker
+// Name carries faker.TestNamePrefix so leaked projects can be swept
 func NewProject() *Project {
 	fake := faker.Project()
 
 	return &Project{
 		OrganizationID:   "ysidaatusqmwbbblhrtn",
 		DatabasePassword: fake.DatabasePassword(),
-		Name:             fake.Name(),
+		Name:             fake.TestName(),
 		Region:           fake.Region(),
 		InstanceSize:     "micro",
 	}
@@ -38,15 +62,16 @@ func NewProject() *Project {
 
 // NewProjectWithFaker creates a new Project instance with all fields from faker
 func NewProjectWithFaker() *Project {
-	fake := faker.Project()
-
-	return &Project{
-		OrganizationID:   fake.OrganizationID(),
-		DatabasePassword: fake.DatabasePassword(),
-		Name:             fake.Name(),
-		Region:           fake.Region(),
-		InstanceSize:     fake.InstanceSize(),
+	project := &Project{}
+	if err := faker.FakeData(project); err != nil {
+		panic(err)
 	}
+	return project
+}
+
+// Validate checks the project fields and returns their violations as an invalid argument error
+func (p *Project) Validate() error {
+	return errors.WithValidateError(validate.Struct(p))
 }
 
 // WithOrganizationID sets a custom organization ID and returns a new Project instance
@@ -130,3 +155,13 @@ func (p *Project) ToMapWithCustomValues(legacyAPIKeysEnabled, moduleEnabled bool
 		"module_enabled":          moduleEnabled,
 	}
 }
+
+// ToTFVars renders the project as a terraform.tfvars file
+func (p *Project) ToTFVars() ([]byte, error) {
+	return tfvars.Encode(p.ToMap())
+}
+
+// ToTFVarsJSON renders the project as a .tfvars.json file
+func (p *Project) ToTFVarsJSON() ([]byte, error) {
+	return tfvars.EncodeJSON(p.ToMap())
+}
@@ -1,9 +1,13 @@
-package supabase
+package fixture
 
 import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
 )
 
 func TestNewProject(t *testing.T) {
@@ -205,3 +209,40 @@ func TestProject_ImmutableWithMethods(t *testing.T) {
 	assert.Equal(t, original.Region, modified.Region)
 	assert.Equal(t, original.InstanceSize, modified.InstanceSize)
 }
+
+func TestProject_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, NewProject().Validate())
+	assert.NoError(t, NewProjectWithFaker().Validate())
+
+	err := NewProject().WithOrganizationID("").WithInstanceSize("huge").Validate()
+	require.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+
+	ie := &errors.Error{}
+	require.True(t, errors.As(err, &ie))
+	assert.ElementsMatch(t, []errors.FieldViolation{
+		{Field: "organization_id", Description: "required"},
+		{Field: "instance_size", Description: "oneof"},
+	}, ie.FieldViolations())
+}
+
+func TestProjectToTFVars(t *testing.T) {
+	t.Parallel()
+
+	project := NewProject().WithName("tftest-varfile").WithDatabasePassword("s3cret!")
+
+	hcl, err := project.ToTFVars()
+	require.NoError(t, err)
+	assert.Contains(t, string(hcl), `database_password       = "s3cret!"`)
+
+	vars, err := tfvars.Decode(hcl, "terraform.tfvars")
+	require.NoError(t, err)
+	assert.Equal(t, project.ToMap(), vars)
+
+	data, err := project.ToTFVarsJSON()
+	require.NoError(t, err)
+	vars, err = tfvars.Decode(data, "terraform.tfvars.json")
+	require.NoError(t, err)
+	assert.Equal(t, project.ToMap(), vars)
+}
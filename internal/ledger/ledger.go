@@ -0,0 +1,168 @@
+package ledger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/lithammer/shortuuid/v3"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// Actions of a record
+const (
+	ActionCreate  = "create"
+	ActionDestroy = "destroy"
+)
+
+// runID identifies the test binary when TF_LEDGER_RUN_ID is not set
+var runID = shortuuid.New()
+
+// mu serializes the appends of parallel tests, O_APPEND keeps separate processes from interleaving
+var mu sync.Mutex
+
+// Record is a line of the ledger, a resource a test created or destroyed
+type Record struct {
+	RunID      string    `json:"run_id"`
+	Test       string    `json:"test"`
+	Module     string    `json:"module"`
+	Address    string    `json:"address"`
+	Type       string    `json:"resource_type"`
+	ID         string    `json:"resource_id"`
+	ProjectRef string    `json:"project_ref,omitempty"`
+	Action     string    `json:"action"`
+	Time       time.Time `json:"timestamp"`
+}
+
+// Key identifies the resource of the record across runs
+func (r Record) Key() string {
+	return r.Type + "/" + r.ID
+}
+
+// Ledger is a JSON Lines file shared by every test of a run, and by later runs
+type Ledger struct {
+	Path  string
+	RunID string
+}
+
+// New returns a Ledger appending to path on behalf of the run
+func New(path, runID string) *Ledger {
+	return &Ledger{Path: path, RunID: runID}
+}
+
+// FromConfig returns the Ledger configured by TF_LEDGER_PATH and TF_LEDGER_RUN_ID
+func FromConfig(conf *config.Config) *Ledger {
+	ledger := New(conf.Ledger.Path, conf.Ledger.RunID)
+	if ledger.Path == "" {
+		ledger.Path = DefaultPath()
+	}
+	if ledger.RunID == "" {
+		ledger.RunID = runID
+	}
+	return ledger
+}
+
+// Default is FromConfig for the environment
+func Default() *Ledger {
+	return FromConfig(config.Must())
+}
+
+// DefaultPath is the ledger used when TF_LEDGER_PATH is not set
+func DefaultPath() string {
+	return filepath.Join(os.TempDir(), "terraform-supabase", "ledger.jsonl")
+}
+
+// Append writes records at the end of the ledger, stamping them with the run ID and, when unset, the current time
+func (l *Ledger) Append(records ...Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for _, record := range records {
+		record.RunID = l.RunID
+		if record.Time.IsZero() {
+			record.Time = now
+		}
+		if err := encoder.Encode(record); err != nil {
+			return errors.Wrap(err, errors.ErrorUnknown, "encode ledger record")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0o755); err != nil {
+		return errors.Wrap(err, errors.ErrorUnknown, "create ledger directory")
+	}
+	file, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644) //nolint:gosec
+	if err != nil {
+		return errors.Wrapf(err, errors.ErrorUnknown, "open ledger %s", l.Path)
+	}
+	defer file.Close()
+	// A single write per call keeps the lines of concurrent processes whole
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		return errors.Wrapf(err, errors.ErrorUnknown, "append to ledger %s", l.Path)
+	}
+	return nil
+}
+
+// Read returns the records of the ledger at path, an ErrorNotFound error when there is none
+func Read(path string) ([]Record, error) {
+	file, err := os.Open(path) //nolint:gosec
+	if os.IsNotExist(err) {
+		return nil, errors.Errorf(errors.ErrorNotFound, "ledger %s not found", path)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.ErrorUnknown, "open ledger %s", path)
+	}
+	defer file.Close()
+
+	records := []Record{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		record := Record{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, errors.Wrapf(err, errors.ErrorInvalidArgument, "%s:%d", path, line)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, errors.ErrorUnknown, "read ledger %s", path)
+	}
+	return records, nil
+}
+
+// Live returns the create records whose resource has no later destroy record, in the order they were created
+func Live(records []Record) []Record {
+	live := map[string]int{}
+	order := []Record{}
+	for _, record := range records {
+		switch record.Action {
+		case ActionCreate:
+			if _, ok := live[record.Key()]; !ok {
+				live[record.Key()] = len(order)
+				order = append(order, record)
+			}
+		case ActionDestroy:
+			delete(live, record.Key())
+		}
+	}
+
+	result := []Record{}
+	for i, record := range order {
+		if j, ok := live[record.Key()]; ok && i == j {
+			result = append(result, record)
+		}
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package ledger
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/mockapi"
+)
+
+func TestAppendRead(t *testing.T) {
+	t.Parallel()
+
+	ledger := New(filepath.Join(t.TempDir(), "nested", "ledger.jsonl"), "run-1")
+	_, err := Read(ledger.Path)
+	assert.True(t, errors.IsKind(err, errors.ErrorNotFound))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, ledger.Append(Record{Type: TypeProject, ID: "ref", Action: ActionCreate}))
+		}()
+	}
+	wg.Wait()
+
+	records, err := Read(ledger.Path)
+	require.NoError(t, err)
+	require.Len(t, records, 20, "concurrent appends keep every line whole")
+	assert.Equal(t, "run-1", records[0].RunID)
+	assert.False(t, records[0].Time.IsZero())
+}
+
+func TestLive(t *testing.T) {
+	t.Parallel()
+
+	records := []Record{
+		{Type: TypeProject, ID: "a", Action: ActionCreate},
+		{Type: TypeAPIKey, ID: "k", ProjectRef: "a", Action: ActionCreate},
+		{Type: TypeProject, ID: "b", Action: ActionCreate},
+		{Type: TypeProject, ID: "a", Action: ActionCreate},
+		{Type: TypeAPIKey, ID: "k", ProjectRef: "a", Action: ActionDestroy},
+		{Type: TypeProject, ID: "b", Action: ActionDestroy},
+		{Type: TypeProject, ID: "b", Action: ActionCreate},
+	}
+	live := Live(records)
+	require.Len(t, live, 2)
+	assert.Equal(t, "supabase_project/a", live[0].Key(), "a second apply does not record a second resource")
+	assert.Equal(t, "supabase_project/b", live[1].Key(), "a resource created again after its destroy is live")
+}
+
+func TestReconcile(t *testing.T) {
+	t.Parallel()
+
+	server := mockapi.New()
+	defer server.Close()
+	client := server.Client()
+	server.AddProject(management.Project{ID: "leaked", OrganizationID: "hadenlabs", Name: "tftest-leaked"})
+	key, err := client.CreateAPIKey(context.Background(), "leaked", management.CreateAPIKey{
+		Type:              management.APIKeyTypeSecret,
+		Name:              "leaked_key",
+		SecretJWTTemplate: &management.SecretJWTTemplate{Role: "service_role"},
+	})
+	require.NoError(t, err)
+
+	records := []Record{
+		{RunID: "run-1", Type: TypeProject, ID: "leaked", ProjectRef: "leaked", Action: ActionCreate},
+		{RunID: "run-1", Type: TypeAPIKey, ID: key.ID, ProjectRef: "leaked", Action: ActionCreate},
+		{RunID: "run-1", Type: TypeProject, ID: "gone", ProjectRef: "gone", Action: ActionCreate},
+		{RunID: "run-2", Type: TypeProject, ID: "destroyed", ProjectRef: "destroyed", Action: ActionCreate},
+		{RunID: "run-2", Type: TypeProject, ID: "destroyed", ProjectRef: "destroyed", Action: ActionDestroy},
+		{RunID: "run-2", Type: "supabase_settings", ID: "leaked", ProjectRef: "leaked", Action: ActionCreate},
+	}
+
+	orphans, err := Reconcile(context.Background(), client, records, "")
+	require.NoError(t, err)
+	require.Len(t, orphans, 3)
+	assert.Equal(t, "leaked", orphans[0].ID)
+	assert.Equal(t, key.ID, orphans[1].ID)
+	assert.True(t, orphans[2].Unverified, "types the API is not asked about are reported unverified")
+
+	orphans, err = Reconcile(context.Background(), client, records, "run-2")
+	require.NoError(t, err)
+	require.Len(t, orphans, 1)
+	assert.Equal(t, "supabase_settings", orphans[0].Type)
+}
+
+func TestFromConfig(t *testing.T) {
+	t.Parallel()
+
+	conf := config.New()
+	ledger := FromConfig(conf)
+	assert.Equal(t, DefaultPath(), ledger.Path)
+	assert.Equal(t, runID, ledger.RunID)
+	assert.Equal(t, ledger.RunID, FromConfig(conf).RunID, "tests of a binary share the run ID")
+
+	conf.Ledger = config.Ledger{Path: "ledger.jsonl", RunID: "1234"}
+	assert.Equal(t, New("ledger.jsonl", "1234"), FromConfig(conf))
+}
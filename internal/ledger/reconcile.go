@@ -0,0 +1,52 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// Resource types the Management API is asked about
+const (
+	TypeProject = "supabase_project"
+	TypeAPIKey  = "supabase_apikey"
+)
+
+// Orphan is a live record whose resource still exists
+type Orphan struct {
+	Record
+
+	// Unverified is set for resource types the Management API is not asked about, which are reported
+	// as orphans until a destroy record says otherwise
+	Unverified bool `json:"unverified,omitempty"`
+}
+
+// Reconcile asks client, the Management API or mockapi, about the live records and returns those whose
+// resource still exists. Records of the other runs are skipped when runID is set.
+func Reconcile(ctx context.Context, client *management.Client, records []Record, runID string) ([]Orphan, error) {
+	orphans := []Orphan{}
+	for _, record := range Live(records) {
+		if runID != "" && record.RunID != runID {
+			continue
+		}
+		var err error
+		switch record.Type {
+		case TypeProject:
+			_, err = client.GetProject(ctx, record.ID)
+		case TypeAPIKey:
+			_, err = client.GetAPIKey(ctx, record.ProjectRef, record.ID)
+		default:
+			orphans = append(orphans, Orphan{Record: record, Unverified: true})
+			continue
+		}
+		if errors.IsKind(err, errors.ErrorNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		orphans = append(orphans, Orphan{Record: record})
+	}
+	return orphans, nil
+}
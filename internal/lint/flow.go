@@ -0,0 +1,262 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+// SensitiveAttributes lists the sensitive attributes of each resource type, as declared by the provider schemas
+// the modules pin (supabase/supabase 1.7.0).
+var SensitiveAttributes = map[string][]string{
+	"supabase_apikey":  {"api_key"},
+	"supabase_project": {"database_password"},
+}
+
+// Flow is a path carrying a sensitive value into a non-sensitive output.
+type Flow struct {
+	// Output is the name of the output the value ends in
+	Output string
+
+	// Source is the sensitive origin, e.g. "var.database_password" or "supabase_apikey.this.api_key"
+	Source string
+
+	// Path lists the locals the value goes through, from the output back to the source
+	Path []string
+
+	// Range is the definition of the output
+	Range hcl.Range
+}
+
+// String renders the flow as output <- locals <- source.
+func (f Flow) String() string {
+	steps := append(append([]string{"output." + f.Output}, f.Path...), f.Source)
+	return strings.Join(steps, " <- ")
+}
+
+// Flows traces references from sensitive variables and sensitive resource attributes through locals
+// and returns every one ending in a non-sensitive output.
+func Flows(module *modules.Module) []Flow {
+	a := newAnalyzer(module)
+	flows := []Flow{}
+	for _, output := range module.Outputs {
+		if output.Sensitive || output.Value == nil {
+			continue
+		}
+		for _, taint := range a.expr(output.Value) {
+			flows = append(flows, Flow{Output: output.Name, Source: taint.source, Path: taint.path, Range: output.Range})
+		}
+	}
+	return flows
+}
+
+// taint is a sensitive source reached by an expression and the locals it went through.
+type taint struct {
+	source string
+	path   []string
+}
+
+type analyzer struct {
+	module *modules.Module
+
+	// locals maps local.NAME, and local.NAME.KEY for object constructors, to their expressions
+	locals map[string]hcl.Expression
+	memo   map[string][]taint
+	active map[string]bool
+}
+
+func newAnalyzer(module *modules.Module) *analyzer {
+	a := &analyzer{
+		module: module,
+		locals: map[string]hcl.Expression{},
+		memo:   map[string][]taint{},
+		active: map[string]bool{},
+	}
+	for _, local := range module.Locals {
+		name := "local." + local.Name
+		a.locals[name] = local.Value
+		object, ok := local.Value.(*hclsyntax.ObjectConsExpr)
+		if !ok {
+			continue
+		}
+		for _, item := range object.Items {
+			if key := hcl.ExprAsKeyword(item.KeyExpr); key != "" {
+				a.locals[name+"."+key] = item.ValueExpr
+			}
+		}
+	}
+	return a
+}
+
+// expr returns the taints reaching expr, deduplicated by source and sorted.
+func (a *analyzer) expr(expr hcl.Expression) []taint {
+	seen := map[string]bool{}
+	taints := []taint{}
+	for _, traversal := range reads(expr) {
+		for _, t := range a.traversal(traversal) {
+			if seen[t.source] {
+				continue
+			}
+			seen[t.source] = true
+			taints = append(taints, t)
+		}
+	}
+	sort.Slice(taints, func(i, j int) bool { return taints[i].source < taints[j].source })
+	return taints
+}
+
+func (a *analyzer) traversal(traversal hcl.Traversal) []taint {
+	names := attrNames(traversal)
+	switch traversal.RootName() {
+	case "var":
+		if len(names) > 0 {
+			if variable, ok := a.module.Variable(names[0]); ok && variable.Sensitive {
+				return []taint{{source: "var." + variable.Name}}
+			}
+		}
+		return nil
+	case "local":
+		return a.local(names)
+	case "data":
+		if len(names) < 2 {
+			return nil
+		}
+		return resourceTaints("data."+names[0], names[0], names[1:])
+	case "module", "path", "terraform", "count", "each", "self":
+		return nil
+	default:
+		return resourceTaints(traversal.RootName(), traversal.RootName(), names)
+	}
+}
+
+func (a *analyzer) local(names []string) []taint {
+	if len(names) == 0 {
+		return nil
+	}
+	name := "local." + names[0]
+	if len(names) > 1 {
+		if _, ok := a.locals[name+"."+names[1]]; ok {
+			return a.node(name + "." + names[1])
+		}
+	}
+	if _, ok := a.locals[name]; !ok {
+		return nil
+	}
+	if _, keyed := a.locals[name].(*hclsyntax.ObjectConsExpr); keyed && len(names) == 1 {
+		// the whole object carries every key, traced in order so the reported paths are stable
+		keys := []string{}
+		for key := range a.locals {
+			if strings.HasPrefix(key, name+".") {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		taints := []taint{}
+		for _, key := range keys {
+			taints = append(taints, a.node(key)...)
+		}
+		return taints
+	}
+	return a.node(name)
+}
+
+// node returns the taints of a local, tracing it once and guarding against reference cycles.
+func (a *analyzer) node(name string) []taint {
+	if taints, ok := a.memo[name]; ok {
+		return taints
+	}
+	if a.active[name] {
+		return nil
+	}
+	a.active[name] = true
+	taints := []taint{}
+	for _, t := range a.expr(a.locals[name]) {
+		taints = append(taints, taint{source: t.source, path: append([]string{name}, t.path...)})
+	}
+	a.active[name] = false
+	a.memo[name] = taints
+	return taints
+}
+
+// resourceTaints returns the sensitive attributes of resourceType read through names, the resource
+// name followed by attribute names. Reading the whole resource reads all of them.
+func resourceTaints(prefix, resourceType string, names []string) []taint {
+	sensitive := SensitiveAttributes[resourceType]
+	if len(names) == 0 || len(sensitive) == 0 {
+		return nil
+	}
+	address := prefix + "." + names[0]
+	if len(names) > 1 {
+		for _, attr := range sensitive {
+			if attr == names[1] {
+				return []taint{{source: address + "." + attr}}
+			}
+		}
+		return nil
+	}
+	taints := make([]taint, 0, len(sensitive))
+	for _, attr := range sensitive {
+		taints = append(taints, taint{source: address + "." + attr})
+	}
+	return taints
+}
+
+// reads returns the traversals read by expr. Unlike hcl.Expression.Variables, the attribute read
+// by a splat is kept: supabase_apikey.this.*.api_key yields supabase_apikey.this.api_key.
+func reads(expr hcl.Expression) []hcl.Traversal {
+	syntax, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		return expr.Variables()
+	}
+	splatted := map[hclsyntax.Node]bool{}
+	traversals := []hcl.Traversal{}
+	_ = hclsyntax.VisitAll(syntax, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch n := node.(type) {
+		case *hclsyntax.SplatExpr:
+			source, ok := n.Source.(*hclsyntax.ScopeTraversalExpr)
+			if !ok {
+				return nil
+			}
+			splatted[source] = true
+			traversal := append(hcl.Traversal{}, source.Traversal...)
+			if each, ok := n.Each.(*hclsyntax.RelativeTraversalExpr); ok {
+				traversal = append(traversal, each.Traversal...)
+			}
+			traversals = append(traversals, traversal)
+		case *hclsyntax.ScopeTraversalExpr:
+			if !splatted[n] {
+				traversals = append(traversals, n.Traversal)
+			}
+		}
+		return nil
+	})
+	return traversals
+}
+
+// attrNames returns the attribute names of a traversal after its root, skipping index steps.
+func attrNames(traversal hcl.Traversal) []string {
+	names := []string{}
+	for _, step := range traversal[1:] {
+		if attr, ok := step.(hcl.TraverseAttr); ok {
+			names = append(names, attr.Name)
+		}
+	}
+	return names
+}
+
+func checkSensitiveFlow(module *modules.Module) []Violation {
+	violations := []Violation{}
+	for _, flow := range Flows(module) {
+		message := fmt.Sprintf("output %s exposes sensitive %s", flow.Output, flow.Source)
+		if len(flow.Path) > 0 {
+			message += " through " + strings.Join(flow.Path, ", ")
+		}
+		violations = append(violations, Violation{Message: message + ", set sensitive = true", Range: flow.Range})
+	}
+	return violations
+}
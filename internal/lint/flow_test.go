@@ -0,0 +1,47 @@
+package lint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+func TestFlows(t *testing.T) {
+	t.Parallel()
+
+	module, err := modules.Load(filepath.Join("testdata", "flow"))
+	require.NoError(t, err)
+
+	flows := map[string][]string{}
+	for _, flow := range Flows(module) {
+		flows[flow.Output] = append(flows[flow.Output], flow.String())
+	}
+	// local.outputs.secret carries the same source as local.outputs.password, the first key in order is reported
+	assert.Equal(t, map[string][]string{
+		"password":         {"output.password <- local.outputs.password <- local.input.password <- var.password"},
+		"outputs":          {"output.outputs <- local.outputs.password <- local.input.password <- var.password"},
+		"project_password": {"output.project_password <- supabase_project.this.database_password"},
+		"apikey":           {"output.apikey <- supabase_apikey.this.api_key"},
+	}, flows)
+
+	messages := []string{}
+	for _, violation := range checkSensitiveFlow(module) {
+		messages = append(messages, violation.Message)
+	}
+	assert.Contains(t, messages, "output password exposes sensitive var.password through local.outputs.password, "+
+		"local.input.password, set sensitive = true")
+}
+
+func TestFlows_Modules(t *testing.T) {
+	t.Parallel()
+
+	discovered, err := modules.Discover(modules.MustRoot())
+	require.NoError(t, err)
+	for _, module := range discovered {
+		assert.Empty(t, Flows(module), module.Name)
+	}
+}
@@ -0,0 +1,138 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+// Severity is the level of a finding, named after SARIF levels.
+type Severity string
+
+// Severities of findings.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Rule is a single module convention.
+type Rule struct {
+	// ID identifies the rule in findings and SARIF output, e.g. "module-enabled-gate"
+	ID string
+
+	// Description explains the convention
+	Description string
+
+	// Severity is the level of the rule's findings
+	Severity Severity
+
+	// Check returns the messages and ranges of the violations in a module
+	Check func(module *modules.Module) []Violation
+}
+
+// Violation is a breach of a rule reported by Rule.Check.
+type Violation struct {
+	Message string
+	Range   hcl.Range
+}
+
+// Finding is a violation attributed to its rule and module.
+type Finding struct {
+	Rule     string
+	Severity Severity
+	Module   string
+	Message  string
+	Range    hcl.Range
+}
+
+// String renders the finding as file:line:column: severity: message [rule].
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s [%s]", f.Range.Filename, f.Range.Start.Line, f.Range.Start.Column,
+		f.Severity, f.Message, f.Rule)
+}
+
+// Lint checks a module against rules, all of Rules when none are given.
+func Lint(module *modules.Module, rules ...Rule) []Finding {
+	if len(rules) == 0 {
+		rules = Rules()
+	}
+	findings := []Finding{}
+	for _, rule := range rules {
+		for _, violation := range rule.Check(module) {
+			findings = append(findings, Finding{
+				Rule:     rule.ID,
+				Severity: rule.Severity,
+				Module:   module.Name,
+				Message:  violation.Message,
+				Range:    violation.Range,
+			})
+		}
+	}
+	sortFindings(findings)
+	return findings
+}
+
+// LintAll checks every module under root/modules. Test fixtures are not checked.
+func LintAll(root string, rules ...Rule) ([]Finding, error) {
+	discovered, err := modules.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	findings := []Finding{}
+	for _, module := range discovered {
+		findings = append(findings, Lint(module, rules...)...)
+	}
+	sortFindings(findings)
+	return findings, nil
+}
+
+// Relative rewrites finding filenames relative to root, as SARIF consumers expect.
+func Relative(root string, findings []Finding) []Finding {
+	out := make([]Finding, 0, len(findings))
+	for _, finding := range findings {
+		if rel, err := filepath.Rel(root, finding.Range.Filename); err == nil {
+			finding.Range.Filename = filepath.ToSlash(rel)
+		}
+		out = append(out, finding)
+	}
+	return out
+}
+
+// WriteText prints one finding per line.
+func WriteText(w io.Writer, findings []Finding) error {
+	for _, finding := range findings {
+		if _, err := fmt.Fprintln(w, finding.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasErrors reports whether any finding has error severity.
+func HasErrors(findings []Finding) bool {
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i].Range, findings[j].Range
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Start.Line != b.Start.Line {
+			return a.Start.Line < b.Start.Line
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+}
@@ -0,0 +1,122 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+func TestLintAll_Modules(t *testing.T) {
+	t.Parallel()
+
+	findings, err := LintAll(modules.MustRoot())
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	module, err := modules.Load(filepath.Join("testdata", "bad"))
+	require.NoError(t, err)
+
+	rules := map[string][]string{}
+	for _, finding := range Lint(module) {
+		assert.Equal(t, "bad", finding.Module)
+		assert.Equal(t, "main.tf", filepath.Base(finding.Range.Filename))
+		rules[finding.Rule] = append(rules[finding.Rule], finding.Message)
+	}
+	assert.Equal(t, map[string][]string{
+		"module-enabled-gate": {
+			"supabase_project.this is not gated on module_enabled, set count = local.outputs.module_enabled ? 1 : 0",
+			"supabase_apikey.this is not gated on module_enabled, set count = local.outputs.module_enabled ? 1 : 0",
+		},
+		"variable-description": {"variable name has no description"},
+		"variable-type":        {"variable password has no type"},
+		"sensitive-output":     {"output password exposes sensitive var.password, set sensitive = true"},
+		"provider-pinned": {
+			`provider supabase version ">= 1.0" is not pinned, use an exact version or ~>`,
+		},
+		"output-one": {"output apikey_id references counted supabase_apikey.this without one()"},
+		"locals-layout": {
+			"locals.generated is missing",
+			"locals.outputs is missing",
+		},
+	}, rules)
+}
+
+func TestPinned(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]bool{
+		"1.7.0":         true,
+		"= 1.7.0":       true,
+		"~> 3.0":        true,
+		"~>3.2.1":       true,
+		">= 2.0":        false,
+		"~> 3.0, < 3.5": false,
+		"":              false,
+		"latest":        false,
+	}
+	for constraint, want := range cases {
+		assert.Equal(t, want, pinned(constraint), constraint)
+	}
+}
+
+func TestUses(t *testing.T) {
+	t.Parallel()
+
+	module := &modules.Module{Resources: []modules.Resource{
+		{Mode: "managed", Type: "supabase_project", Name: "this"},
+		{Mode: "data", Type: "null_data_source", Name: "values"},
+	}}
+	assert.True(t, uses(module, "supabase"))
+	assert.True(t, uses(module, "null"), "data sources use their provider")
+	assert.False(t, uses(module, "random"))
+	assert.False(t, uses(module, "supa"))
+}
+
+func TestWriteSARIF(t *testing.T) {
+	t.Parallel()
+
+	module, err := modules.Load(filepath.Join("testdata", "bad"))
+	require.NoError(t, err)
+	root, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+	findings := Relative(root, Lint(module))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(&buf, Rules(), findings))
+
+	log := sarifLog{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	assert.Equal(t, ToolName, log.Runs[0].Tool.Driver.Name)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(Rules()))
+	require.Len(t, log.Runs[0].Results, len(findings))
+
+	result := log.Runs[0].Results[0]
+	assert.Equal(t, "bad/main.tf", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Positive(t, result.Locations[0].PhysicalLocation.Region.StartLine)
+}
+
+func TestWriteText(t *testing.T) {
+	t.Parallel()
+
+	module, err := modules.Load(filepath.Join("testdata", "bad"))
+	require.NoError(t, err)
+	findings := Lint(module)
+	assert.True(t, HasErrors(findings))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteText(&buf, Relative(filepath.Dir(module.Dir), findings)))
+	assert.Contains(t, buf.String(), "bad/main.tf:3:5: error: provider supabase")
+	assert.Contains(t, buf.String(), "error: variable password has no type [variable-type]")
+}
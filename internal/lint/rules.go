@@ -0,0 +1,231 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+// moduleEnabled is the variable gating every resource of a module
+const moduleEnabled = "module_enabled"
+
+// conventionalLocals are the locals every module derives its resources from
+var conventionalLocals = []string{"input", "generated", "outputs"}
+
+// Rules returns every module convention.
+func Rules() []Rule {
+	return []Rule{
+		{
+			ID:          "module-enabled-gate",
+			Description: "Every resource sets count from module_enabled so the module can be disabled",
+			Severity:    SeverityError,
+			Check:       checkModuleEnabledGate,
+		},
+		{
+			ID:          "variable-description",
+			Description: "Every variable has a description",
+			Severity:    SeverityWarning,
+			Check:       checkVariableDescription,
+		},
+		{
+			ID:          "variable-type",
+			Description: "Every variable declares a type",
+			Severity:    SeverityError,
+			Check:       checkVariableType,
+		},
+		{
+			ID:          "sensitive-output",
+			Description: "Outputs carrying a sensitive variable or resource attribute, directly or through locals, are sensitive",
+			Severity:    SeverityError,
+			Check:       checkSensitiveFlow,
+		},
+		{
+			ID:          "provider-pinned",
+			Description: "Versions of the providers resources use are pinned to an exact version or a ~> range",
+			Severity:    SeverityError,
+			Check:       checkProviderPinned,
+		},
+		{
+			ID:          "output-one",
+			Description: "Outputs of counted resources unwrap the splat with one()",
+			Severity:    SeverityWarning,
+			Check:       checkOutputOne,
+		},
+		{
+			ID:          "locals-layout",
+			Description: "Locals are organized as input, generated and outputs",
+			Severity:    SeverityNote,
+			Check:       checkLocalsLayout,
+		},
+	}
+}
+
+func checkModuleEnabledGate(module *modules.Module) []Violation {
+	violations := []Violation{}
+	for _, resource := range module.Resources {
+		if resource.Mode != "managed" {
+			continue
+		}
+		if resource.Count == nil || !references(resource.Count, moduleEnabled) {
+			violations = append(violations, Violation{
+				Message: fmt.Sprintf("%s is not gated on %s, set count = local.outputs.%s ? 1 : 0",
+					resource.Address(), moduleEnabled, moduleEnabled),
+				Range: resource.Range,
+			})
+		}
+	}
+	return violations
+}
+
+func checkVariableDescription(module *modules.Module) []Violation {
+	violations := []Violation{}
+	for _, variable := range module.Variables {
+		if strings.TrimSpace(variable.Description) == "" {
+			violations = append(violations, Violation{
+				Message: fmt.Sprintf("variable %s has no description", variable.Name),
+				Range:   variable.Range,
+			})
+		}
+	}
+	return violations
+}
+
+func checkVariableType(module *modules.Module) []Violation {
+	violations := []Violation{}
+	for _, variable := range module.Variables {
+		if variable.Type == "" {
+			violations = append(violations, Violation{
+				Message: fmt.Sprintf("variable %s has no type", variable.Name),
+				Range:   variable.Range,
+			})
+		}
+	}
+	return violations
+}
+
+func checkProviderPinned(module *modules.Module) []Violation {
+	violations := []Violation{}
+	for _, provider := range module.Providers {
+		if !uses(module, provider.Name) {
+			// a requirement no resource uses selects nothing that runs
+			continue
+		}
+		if !pinned(provider.Version) {
+			violations = append(violations, Violation{
+				Message: fmt.Sprintf("provider %s version %q is not pinned, use an exact version or ~>", provider.Name, provider.Version),
+				Range:   provider.Range,
+			})
+		}
+	}
+	return violations
+}
+
+func checkOutputOne(module *modules.Module) []Violation {
+	counted := map[string]bool{}
+	for _, resource := range module.Resources {
+		if resource.Count != nil {
+			counted[resource.Type+"."+resource.Name] = true
+		}
+	}
+	violations := []Violation{}
+	for _, output := range module.Outputs {
+		if output.Value == nil {
+			continue
+		}
+		for _, traversal := range output.Value.Variables() {
+			if len(traversal) < 2 {
+				continue
+			}
+			attr, ok := traversal[1].(hcl.TraverseAttr)
+			if !ok || !counted[traversal.RootName()+"."+attr.Name] {
+				continue
+			}
+			if !callsOne(output.Value) {
+				violations = append(violations, Violation{
+					Message: fmt.Sprintf("output %s references counted %s.%s without one()", output.Name, traversal.RootName(), attr.Name),
+					Range:   output.Range,
+				})
+			}
+			break
+		}
+	}
+	return violations
+}
+
+func checkLocalsLayout(module *modules.Module) []Violation {
+	if len(module.Resources) == 0 {
+		return nil
+	}
+	defined := map[string]bool{}
+	for _, local := range module.Locals {
+		defined[local.Name] = true
+	}
+	violations := []Violation{}
+	for _, name := range conventionalLocals {
+		if !defined[name] {
+			violations = append(violations, Violation{
+				Message: fmt.Sprintf("locals.%s is missing", name),
+				Range:   module.Resources[0].Range,
+			})
+		}
+	}
+	return violations
+}
+
+// references reports whether expr reads an attribute or variable called name.
+func references(expr hcl.Expression, name string) bool {
+	for _, traversal := range expr.Variables() {
+		for _, step := range traversal {
+			if attr, ok := step.(hcl.TraverseAttr); ok && attr.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// callsOne reports whether expr contains a call to one().
+func callsOne(expr hcl.Expression) bool {
+	syntax, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		return false
+	}
+	found := false
+	_ = hclsyntax.VisitAll(syntax, func(node hclsyntax.Node) hcl.Diagnostics {
+		if call, ok := node.(*hclsyntax.FunctionCallExpr); ok && call.Name == "one" {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+// uses reports whether a resource or data source of module, both in module.Resources, belongs to the provider
+// name.
+func uses(module *modules.Module, name string) bool {
+	for _, resource := range module.Resources {
+		if resource.Type == name || strings.HasPrefix(resource.Type, name+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// pinned reports whether a version constraint is an exact version or a single ~> range.
+func pinned(constraint string) bool {
+	constraint = strings.TrimSpace(constraint)
+	if constraint == "" || strings.Contains(constraint, ",") {
+		return false
+	}
+	if strings.HasPrefix(constraint, "~>") {
+		_, err := version.NewVersion(strings.TrimSpace(strings.TrimPrefix(constraint, "~>")))
+		return err == nil
+	}
+	_, err := version.NewVersion(strings.TrimSpace(strings.TrimPrefix(constraint, "=")))
+	return err == nil
+}
@@ -0,0 +1,46 @@
+terraform {
+  required_providers {
+    supabase = {
+      source  = "supabase/supabase"
+      version = ">= 1.0"
+    }
+
+    null = {
+      source  = "hashicorp/null"
+      version = ">= 2.0"
+    }
+  }
+}
+
+variable "name" {
+  type = string
+}
+
+variable "password" {
+  description = "(Required) Database password"
+  sensitive   = true
+}
+
+locals {
+  input = {
+    name = var.name
+  }
+}
+
+resource "supabase_project" "this" {
+  name              = local.input.name
+  database_password = var.password
+}
+
+resource "supabase_apikey" "this" {
+  count = 1
+  name  = local.input.name
+}
+
+output "password" {
+  value = var.password
+}
+
+output "apikey_id" {
+  value = supabase_apikey.this.*.id
+}
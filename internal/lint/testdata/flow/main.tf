@@ -0,0 +1,72 @@
+variable "password" {
+  description = "(Required) Database password"
+  type        = string
+  sensitive   = true
+}
+
+variable "name" {
+  description = "(Required) Name of the project"
+  type        = string
+}
+
+locals {
+  input = {
+    name     = var.name
+    password = var.password
+  }
+
+  outputs = {
+    name     = local.input.name
+    password = local.input.password
+    secret   = local.input.password
+  }
+
+  loop     = local.circular
+  circular = local.loop
+}
+
+resource "supabase_project" "this" {
+  count = 1
+
+  name              = local.outputs.name
+  database_password = local.outputs.password
+}
+
+resource "supabase_apikey" "this" {
+  count = 1
+
+  name = local.outputs.name
+}
+
+output "name" {
+  value = local.outputs.name
+}
+
+output "password" {
+  value = local.outputs.password
+}
+
+output "password_sensitive" {
+  value     = local.outputs.password
+  sensitive = true
+}
+
+output "outputs" {
+  value = local.outputs
+}
+
+output "project_id" {
+  value = one(supabase_project.this.*.id)
+}
+
+output "project_password" {
+  value = one(supabase_project.this.*.database_password)
+}
+
+output "apikey" {
+  value = supabase_apikey.this[0]
+}
+
+output "loop" {
+  value = local.loop
+}
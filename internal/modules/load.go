@@ -0,0 +1,282 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
+)
+
+const (
+	// ModulesDir is the directory, relative to the repository root, holding the modules
+	ModulesDir = "modules"
+
+	// FixturesDir is the directory, relative to a module, holding its test fixtures
+	FixturesDir = "test"
+)
+
+// Discover loads every module under root/modules together with its test fixtures.
+func Discover(root string) ([]*Module, error) {
+	dirs, err := subdirs(filepath.Join(root, ModulesDir))
+	if err != nil {
+		return nil, err
+	}
+	modules := make([]*Module, 0, len(dirs))
+	for _, dir := range dirs {
+		module, err := Load(dir)
+		if err != nil {
+			return nil, err
+		}
+		fixtureDirs, err := subdirs(filepath.Join(dir, FixturesDir))
+		if err != nil && !errors.IsKind(err, errors.ErrorNotFound) {
+			return nil, err
+		}
+		for _, fixtureDir := range fixtureDirs {
+			fixture, err := Load(fixtureDir)
+			if err != nil {
+				return nil, err
+			}
+			module.Fixtures = append(module.Fixtures, fixture)
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// Find loads the module with the given name from root/modules.
+func Find(root, name string) (*Module, error) {
+	modules, err := Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, module := range modules {
+		if module.Name == name {
+			return module, nil
+		}
+	}
+	return nil, errors.Errorf(errors.ErrorNotFound, "module %s not found in %s", name, filepath.Join(root, ModulesDir))
+}
+
+// Load parses every .tf file of dir into a Module.
+func Load(dir string) (*Module, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.ErrorInvalidArgument, "resolve %s", dir)
+	}
+	files, err := filepath.Glob(filepath.Join(abs, "*.tf"))
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.ErrorInvalidArgument, "list %s", abs)
+	}
+	if len(files) == 0 {
+		return nil, errors.Errorf(errors.ErrorNotFound, "no terraform files in %s", abs)
+	}
+	sort.Strings(files)
+
+	module := &Module{Name: filepath.Base(abs), Dir: abs, sources: map[string][]byte{}}
+	parser := hclparse.NewParser()
+	for _, filename := range files {
+		file, diags := parser.ParseHCLFile(filename)
+		if diags.HasErrors() {
+			return nil, errors.Wrapf(diags, errors.ErrorInvalidArgument, "parse %s", filename)
+		}
+		module.sources[filename] = file.Bytes
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			return nil, errors.Errorf(errors.ErrorInvalidArgument, "unexpected body in %s", filename)
+		}
+		if err := module.decode(body); err != nil {
+			return nil, errors.Wrapf(err, errors.ErrorInvalidArgument, "decode %s", filename)
+		}
+	}
+	return module, nil
+}
+
+func (m *Module) decode(body *hclsyntax.Body) error {
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "terraform":
+			m.decodeTerraform(block.Body)
+		case "variable":
+			variable, err := m.decodeVariable(block)
+			if err != nil {
+				return err
+			}
+			m.Variables = append(m.Variables, variable)
+		case "output":
+			m.Outputs = append(m.Outputs, Output{
+				Name:        label(block, 0),
+				Description: stringAttr(block.Body, "description"),
+				Sensitive:   boolAttr(block.Body, "sensitive"),
+				Value:       expr(block.Body, "value"),
+				Range:       block.DefRange(),
+			})
+		case "locals":
+			for _, attr := range sortedAttributes(block.Body) {
+				m.Locals = append(m.Locals, Local{Name: attr.Name, Value: attr.Expr, Range: attr.SrcRange})
+			}
+		case "resource", "data":
+			m.Resources = append(m.Resources, decodeResource(block))
+		case "module":
+			m.Calls = append(m.Calls, Call{
+				Name:   label(block, 0),
+				Source: stringAttr(block.Body, "source"),
+				Range:  block.DefRange(),
+			})
+		}
+	}
+	return nil
+}
+
+func (m *Module) decodeTerraform(body *hclsyntax.Body) {
+	if version := stringAttr(body, "required_version"); version != "" {
+		m.RequiredVersion = version
+	}
+	for _, block := range body.Blocks {
+		if block.Type != "required_providers" {
+			continue
+		}
+		for _, attr := range sortedAttributes(block.Body) {
+			provider := Provider{Name: attr.Name, Range: attr.SrcRange}
+			value, diags := attr.Expr.Value(nil)
+			if !diags.HasErrors() && value.Type().IsObjectType() {
+				provider.Source = objectString(value, "source")
+				provider.Version = objectString(value, "version")
+			}
+			m.Providers = append(m.Providers, provider)
+		}
+	}
+}
+
+func (m *Module) decodeVariable(block *hclsyntax.Block) (Variable, error) {
+	variable := Variable{
+		Name:        label(block, 0),
+		Type:        m.Source(expr(block.Body, "type")),
+		TypeExpr:    expr(block.Body, "type"),
+		Description: stringAttr(block.Body, "description"),
+		Sensitive:   boolAttr(block.Body, "sensitive"),
+		Range:       block.DefRange(),
+	}
+	attr, ok := block.Body.Attributes["default"]
+	if !ok {
+		return variable, nil
+	}
+	variable.HasDefault = true
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() {
+		return variable, errors.Wrapf(diags, errors.ErrorInvalidArgument, "default of variable %s", variable.Name)
+	}
+	goValue, err := tfvars.ToGo(value)
+	if err != nil {
+		return variable, errors.Wrapf(err, errors.ErrorInvalidArgument, "default of variable %s", variable.Name)
+	}
+	variable.Default = goValue
+	return variable, nil
+}
+
+func decodeResource(block *hclsyntax.Block) Resource {
+	mode := "managed"
+	if block.Type == "data" {
+		mode = "data"
+	}
+	resource := Resource{
+		Mode:       mode,
+		Type:       label(block, 0),
+		Name:       label(block, 1),
+		Count:      expr(block.Body, "count"),
+		ForEach:    expr(block.Body, "for_each"),
+		Attributes: map[string]hcl.Expression{},
+		Range:      block.DefRange(),
+	}
+	for name, attr := range block.Body.Attributes {
+		resource.Attributes[name] = attr.Expr
+	}
+	return resource
+}
+
+func subdirs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, errors.ErrorNotFound, "read %s", dir)
+		}
+		return nil, errors.Wrapf(err, errors.ErrorInvalidArgument, "read %s", dir)
+	}
+	dirs := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if files, _ := filepath.Glob(filepath.Join(path, "*.tf")); len(files) > 0 {
+			dirs = append(dirs, path)
+		}
+	}
+	return dirs, nil
+}
+
+func sortedAttributes(body *hclsyntax.Body) []*hclsyntax.Attribute {
+	attrs := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attr := range body.Attributes {
+		attrs = append(attrs, attr)
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].SrcRange.Start.Byte < attrs[j].SrcRange.Start.Byte
+	})
+	return attrs
+}
+
+func label(block *hclsyntax.Block, i int) string {
+	if i < len(block.Labels) {
+		return block.Labels[i]
+	}
+	return ""
+}
+
+func expr(body *hclsyntax.Body, name string) hcl.Expression {
+	if attr, ok := body.Attributes[name]; ok {
+		return attr.Expr
+	}
+	return nil
+}
+
+func stringAttr(body *hclsyntax.Body, name string) string {
+	attr, ok := body.Attributes[name]
+	if !ok {
+		return ""
+	}
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.Type().Equals(cty.String) {
+		return ""
+	}
+	return value.AsString()
+}
+
+func boolAttr(body *hclsyntax.Body, name string) bool {
+	attr, ok := body.Attributes[name]
+	if !ok {
+		return false
+	}
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.Type().Equals(cty.Bool) {
+		return false
+	}
+	return value.True()
+}
+
+func objectString(value cty.Value, name string) string {
+	if !value.Type().HasAttribute(name) {
+		return ""
+	}
+	attr := value.GetAttr(name)
+	if attr.IsNull() || !attr.Type().Equals(cty.String) {
+		return ""
+	}
+	return attr.AsString()
+}
@@ -0,0 +1,186 @@
+package modules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Module is a directory of Terraform configuration, either a module under modules/ or one of its test fixtures.
+type Module struct {
+	// Name is the directory name, e.g. "project" or "project-basic"
+	Name string
+
+	// Dir is the absolute path of the directory
+	Dir string
+
+	// RequiredVersion is the Terraform version constraint from the terraform block
+	RequiredVersion string
+
+	// Providers are the entries of required_providers
+	Providers []Provider
+
+	// Variables are the declared input variables
+	Variables []Variable
+
+	// Outputs are the declared outputs
+	Outputs []Output
+
+	// Locals are the attributes of every locals block
+	Locals []Local
+
+	// Resources are the managed resources and data sources
+	Resources []Resource
+
+	// Calls are the module blocks, used by test fixtures
+	Calls []Call
+
+	// Fixtures are the test configurations under test/
+	Fixtures []*Module
+
+	sources map[string][]byte
+}
+
+// Provider is a required_providers entry.
+type Provider struct {
+	Name    string
+	Source  string
+	Version string
+	Range   hcl.Range
+}
+
+// Variable is an input variable block.
+type Variable struct {
+	Name        string
+	Type        string
+	TypeExpr    hcl.Expression
+	Description string
+	Default     interface{}
+	HasDefault  bool
+	Sensitive   bool
+	Range       hcl.Range
+}
+
+// Required reports whether callers must set the variable.
+func (v Variable) Required() bool {
+	return !v.HasDefault
+}
+
+// Output is an output block.
+type Output struct {
+	Name        string
+	Description string
+	Sensitive   bool
+	Value       hcl.Expression
+	Range       hcl.Range
+}
+
+// Local is a single attribute of a locals block.
+type Local struct {
+	Name  string
+	Value hcl.Expression
+	Range hcl.Range
+}
+
+// Resource is a resource or data block.
+type Resource struct {
+	// Mode is "managed" for resource blocks and "data" for data blocks
+	Mode    string
+	Type    string
+	Name    string
+	Count   hcl.Expression
+	ForEach hcl.Expression
+	// Attributes are the top-level arguments of the block
+	Attributes map[string]hcl.Expression
+	Range      hcl.Range
+}
+
+// Address returns the resource address without instance keys, e.g. "supabase_project.this".
+func (r Resource) Address() string {
+	if r.Mode == "data" {
+		return fmt.Sprintf("data.%s.%s", r.Type, r.Name)
+	}
+	return fmt.Sprintf("%s.%s", r.Type, r.Name)
+}
+
+// Call is a module block.
+type Call struct {
+	Name   string
+	Source string
+	Range  hcl.Range
+}
+
+// Variable returns the variable with the given name.
+func (m *Module) Variable(name string) (Variable, bool) {
+	for _, variable := range m.Variables {
+		if variable.Name == name {
+			return variable, true
+		}
+	}
+	return Variable{}, false
+}
+
+// Output returns the output with the given name.
+func (m *Module) Output(name string) (Output, bool) {
+	for _, output := range m.Outputs {
+		if output.Name == name {
+			return output, true
+		}
+	}
+	return Output{}, false
+}
+
+// Provider returns the required provider with the given local name.
+func (m *Module) Provider(name string) (Provider, bool) {
+	for _, provider := range m.Providers {
+		if provider.Name == name {
+			return provider, true
+		}
+	}
+	return Provider{}, false
+}
+
+// Fixture returns the test fixture with the given directory name.
+func (m *Module) Fixture(name string) (*Module, bool) {
+	for _, fixture := range m.Fixtures {
+		if fixture.Name == name {
+			return fixture, true
+		}
+	}
+	return nil, false
+}
+
+// RequiredVariables returns the variables without a default.
+func (m *Module) RequiredVariables() []Variable {
+	required := []Variable{}
+	for _, variable := range m.Variables {
+		if variable.Required() {
+			required = append(required, variable)
+		}
+	}
+	return required
+}
+
+// Defaults returns the default value of every variable that declares one.
+func (m *Module) Defaults() map[string]interface{} {
+	defaults := map[string]interface{}{}
+	for _, variable := range m.Variables {
+		if variable.HasDefault {
+			defaults[variable.Name] = variable.Default
+		}
+	}
+	return defaults
+}
+
+// Source returns the source text of an expression with whitespace collapsed.
+func (m *Module) Source(expr hcl.Expression) string {
+	if expr == nil {
+		return ""
+	}
+	src, ok := m.sources[expr.Range().Filename]
+	if !ok {
+		return ""
+	}
+	return strings.Join(strings.Fields(string(expr.Range().SliceBytes(src))), " ")
+}
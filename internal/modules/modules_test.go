@@ -0,0 +1,177 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+func TestRoot(t *testing.T) {
+	t.Parallel()
+
+	root, err := Root()
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(root, "go.mod"))
+	assert.DirExists(t, filepath.Join(root, ModulesDir))
+}
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	modules, err := Discover(MustRoot())
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, module := range modules {
+		names = append(names, module.Name)
+	}
+	assert.Equal(t, []string{"apikey", "project"}, names)
+}
+
+func TestFind_Project(t *testing.T) {
+	t.Parallel()
+
+	project, err := Find(MustRoot(), "project")
+	require.NoError(t, err)
+
+	assert.Equal(t, ">= 1.0.0", project.RequiredVersion)
+
+	supabase, ok := project.Provider("supabase")
+	require.True(t, ok)
+	assert.Equal(t, "supabase/supabase", supabase.Source)
+	assert.Equal(t, "1.7.0", supabase.Version)
+
+	password, ok := project.Variable("database_password")
+	require.True(t, ok)
+	assert.Equal(t, "string", password.Type)
+	assert.True(t, password.Sensitive)
+	assert.True(t, password.Required())
+
+	region, ok := project.Variable("region")
+	require.True(t, ok)
+	assert.False(t, region.Required())
+	assert.Equal(t, "us-east-1", region.Default)
+
+	legacy, ok := project.Variable("legacy_api_keys_enabled")
+	require.True(t, ok)
+	assert.True(t, legacy.HasDefault)
+	assert.Nil(t, legacy.Default)
+	assert.Contains(t, legacy.Description, "(Optional, Deprecated)")
+
+	assert.Equal(t, map[string]interface{}{
+		"region":                  "us-east-1",
+		"instance_size":           "micro",
+		"legacy_api_keys_enabled": nil,
+		"module_enabled":          true,
+	}, project.Defaults())
+
+	required := []string{}
+	for _, variable := range project.RequiredVariables() {
+		required = append(required, variable.Name)
+	}
+	assert.ElementsMatch(t, []string{"database_password", "name", "organization_id"}, required)
+
+	require.Len(t, project.Resources, 1)
+	resource := project.Resources[0]
+	assert.Equal(t, "supabase_project.this", resource.Address())
+	assert.Equal(t, "local.outputs.module_enabled ? 1 : 0", project.Source(resource.Count))
+
+	id, ok := project.Output("id")
+	require.True(t, ok)
+	assert.Contains(t, project.Source(id.Value), "one(supabase_project.this.*.id)")
+
+	locals := []string{}
+	for _, local := range project.Locals {
+		locals = append(locals, local.Name)
+	}
+	assert.Equal(t, []string{"defaults", "input", "generated", "outputs"}, locals)
+}
+
+func TestFind_Fixtures(t *testing.T) {
+	t.Parallel()
+
+	apikey, err := Find(MustRoot(), "apikey")
+	require.NoError(t, err)
+
+	basic, ok := apikey.Fixture("apikey-basic")
+	require.True(t, ok)
+	_, ok = apikey.Fixture("apikey-disabled")
+	assert.True(t, ok)
+
+	calls := map[string]string{}
+	for _, call := range basic.Calls {
+		calls[call.Name] = call.Source
+	}
+	assert.Equal(t, map[string]string{
+		"supabase_project": "../../../project",
+		"supabase_apikey":  "../..",
+	}, calls)
+	_, ok = basic.Variable("apikey_name")
+	assert.True(t, ok)
+}
+
+func TestFind_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := Find(MustRoot(), "storage")
+	assert.True(t, errors.IsKind(err, errors.ErrorNotFound))
+}
+
+func TestLoad_Empty(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load(t.TempDir())
+	assert.True(t, errors.IsKind(err, errors.ErrorNotFound))
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte("variable \"x\" {"), 0o600))
+
+	_, err := Load(dir)
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+}
+
+func TestModule_Validate(t *testing.T) {
+	t.Parallel()
+
+	apikey, err := Find(MustRoot(), "apikey")
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, apikey.Validate(map[string]interface{}{
+			"project_id":     "abcdefghijklmnopqrst",
+			"name":           "ci_key",
+			"module_enabled": "true",
+		}))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		err := apikey.Validate(map[string]interface{}{
+			"name":           "ci_key",
+			"module_enabled": map[string]interface{}{"enabled": true},
+			"project_ref":    "abcdefghijklmnopqrst",
+		})
+		require.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+
+		ie := &errors.Error{}
+		require.True(t, errors.As(err, &ie))
+		fields := map[string]string{}
+		for _, violation := range ie.FieldViolations() {
+			fields[violation.Field] = violation.Description
+		}
+		assert.Equal(t, "required", fields["project_id"])
+		assert.Equal(t, "unknown variable", fields["project_ref"])
+		assert.Contains(t, fields["module_enabled"], "expected bool")
+		assert.NotContains(t, fields, "name")
+	})
+}
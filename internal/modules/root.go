@@ -0,0 +1,58 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// Root returns the repository root: the closest directory, walking up from the working
+// directory, that holds both go.mod and modules/. When the working directory lies outside
+// the repository it falls back to the location of this source file.
+func Root() (string, error) {
+	if wd, err := os.Getwd(); err == nil {
+		if root, ok := findRoot(wd); ok {
+			return root, nil
+		}
+	}
+	if _, file, _, ok := runtime.Caller(0); ok {
+		if root, ok := findRoot(filepath.Dir(file)); ok {
+			return root, nil
+		}
+	}
+	return "", errors.New(errors.ErrorNotFound, "repository root not found")
+}
+
+// MustRoot is like Root but panics when the root cannot be found.
+func MustRoot() string {
+	root, err := Root()
+	if err != nil {
+		panic(err)
+	}
+	return root
+}
+
+func findRoot(dir string) (string, bool) {
+	for {
+		if isFile(filepath.Join(dir, "go.mod")) && isDir(filepath.Join(dir, ModulesDir)) {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,88 @@
+package modules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
+)
+
+// Validate checks variable values, as read from a tfvars file, against the declared variables.
+// Every unknown name, missing required variable and type mismatch is reported as a field violation.
+func (m *Module) Validate(vars map[string]interface{}) error {
+	violations := []errors.FieldViolation{}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		variable, ok := m.Variable(name)
+		if !ok {
+			violations = append(violations, errors.FieldViolation{Field: name, Description: "unknown variable"})
+			continue
+		}
+		if err := variable.check(vars[name]); err != nil {
+			violations = append(violations, errors.FieldViolation{Field: name, Description: err.Error()})
+		}
+	}
+	for _, variable := range m.RequiredVariables() {
+		if _, ok := vars[variable.Name]; !ok {
+			violations = append(violations, errors.FieldViolation{Field: variable.Name, Description: "required"})
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.WithFieldViolations(errors.ErrorInvalidArgument, fmt.Sprintf("invalid variables for module %s", m.Name), violations)
+}
+
+// TypeConstraint returns the cty type of the variable, cty.DynamicPseudoType when it has none.
+func (v Variable) TypeConstraint() (cty.Type, error) {
+	expr := v.TypeExpr
+	if expr == nil {
+		if v.Type == "" {
+			return cty.DynamicPseudoType, nil
+		}
+		parsed, diags := hclsyntax.ParseExpression([]byte(v.Type), v.Range.Filename, hcl.InitialPos)
+		if diags.HasErrors() {
+			return cty.NilType, diags
+		}
+		expr = parsed
+	}
+	ty, diags := typeexpr.TypeConstraint(expr)
+	if diags.HasErrors() {
+		return cty.NilType, diags
+	}
+	return ty, nil
+}
+
+func (v Variable) check(value interface{}) error {
+	if value == nil {
+		if v.Required() {
+			return errors.New(errors.ErrorInvalidArgument, "required, got null")
+		}
+		return nil
+	}
+	ty, err := v.TypeConstraint()
+	if err != nil {
+		return errors.Wrapf(err, errors.ErrorInvalidArgument, "type %s", v.Type)
+	}
+	converted, err := tfvars.ToCty(value)
+	if err != nil {
+		return err
+	}
+	if _, err := convert.Convert(converted, ty); err != nil {
+		return errors.Errorf(errors.ErrorInvalidArgument, "expected %s: %s", v.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// Actions of a resource change
+const (
+	ActionCreate  = "create"
+	ActionRead    = "read"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionReplace = "replace"
+)
+
+var actionSymbols = map[string]string{
+	ActionCreate:  "+",
+	ActionRead:    "<=",
+	ActionUpdate:  "~",
+	ActionDelete:  "-",
+	ActionReplace: "-/+",
+}
+
+// Changes are the pending changes of a plan
+type Changes struct {
+	// Resources are the changes terraform would apply
+	Resources []ResourceChange
+
+	// Drift are the changes made outside of terraform, detected while refreshing
+	Drift []ResourceChange
+}
+
+// ResourceChange is the change of a single resource instance
+type ResourceChange struct {
+	Address    string
+	Action     string
+	Attributes []AttributeChange
+}
+
+// AttributeChange is the change of a single attribute, nested attributes use paths such as settings.name or tags[0]
+type AttributeChange struct {
+	Path              string
+	Before            interface{}
+	After             interface{}
+	Unknown           bool
+	Sensitive         bool
+	ForcesReplacement bool
+}
+
+// planJSON is the part of `terraform show -json` the changes are read from
+type planJSON struct {
+	ResourceChanges []resourceChangeJSON `json:"resource_changes"`
+	ResourceDrift   []resourceChangeJSON `json:"resource_drift"`
+}
+
+type resourceChangeJSON struct {
+	Address string `json:"address"`
+	Change  struct {
+		Actions         []string        `json:"actions"`
+		Before          interface{}     `json:"before"`
+		After           interface{}     `json:"after"`
+		AfterUnknown    interface{}     `json:"after_unknown"`
+		BeforeSensitive interface{}     `json:"before_sensitive"`
+		AfterSensitive  interface{}     `json:"after_sensitive"`
+		ReplacePaths    [][]interface{} `json:"replace_paths"`
+	} `json:"change"`
+}
+
+// ParsePlan reads the changes of a plan rendered by `terraform show -json`
+func ParsePlan(data []byte) (*Changes, error) {
+	plan := planJSON{}
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, errors.Wrap(err, errors.ErrorInvalidArgument, "parse plan json")
+	}
+	return &Changes{Resources: resourceChanges(plan.ResourceChanges), Drift: resourceChanges(plan.ResourceDrift)}, nil
+}
+
+func resourceChanges(in []resourceChangeJSON) []ResourceChange {
+	changes := []ResourceChange{}
+	for _, rc := range in {
+		action := actionOf(rc.Change.Actions)
+		if action == "" {
+			continue
+		}
+		change := ResourceChange{Address: rc.Address, Action: action}
+
+		before, after := map[string]interface{}{}, map[string]interface{}{}
+		flatten("", rc.Change.Before, before)
+		flatten("", rc.Change.After, after)
+		unknown, sensitive := map[string]interface{}{}, map[string]interface{}{}
+		flatten("", rc.Change.AfterUnknown, unknown)
+		flatten("", rc.Change.BeforeSensitive, sensitive)
+		flatten("", rc.Change.AfterSensitive, sensitive)
+		replace := []string{}
+		for _, path := range rc.Change.ReplacePaths {
+			replace = append(replace, joinPath(path))
+		}
+
+		paths := map[string]bool{}
+		for path := range before {
+			paths[path] = true
+		}
+		for path := range after {
+			paths[path] = true
+		}
+		for path, v := range unknown {
+			if v == true {
+				paths[path] = true
+			}
+		}
+		for path := range paths {
+			attr := AttributeChange{
+				Path:              path,
+				Before:            before[path],
+				After:             after[path],
+				Unknown:           unknown[path] == true,
+				Sensitive:         sensitive[path] == true,
+				ForcesReplacement: forces(replace, path),
+			}
+			if !attr.Unknown && reflect.DeepEqual(attr.Before, attr.After) {
+				continue
+			}
+			change.Attributes = append(change.Attributes, attr)
+		}
+		sort.Slice(change.Attributes, func(i, j int) bool { return change.Attributes[i].Path < change.Attributes[j].Path })
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// Empty reports whether the plan has nothing to apply
+func (c *Changes) Empty() bool {
+	return len(c.Resources) == 0
+}
+
+// Resource returns the change of the resource at address
+func (c *Changes) Resource(address string) (ResourceChange, bool) {
+	for _, change := range c.Resources {
+		if change.Address == address {
+			return change, true
+		}
+	}
+	return ResourceChange{}, false
+}
+
+// Replaced returns the changes that destroy a resource, replacing it or not
+func (c *Changes) Replaced() []ResourceChange {
+	changes := []ResourceChange{}
+	for _, change := range c.Resources {
+		if change.Action == ActionReplace || change.Action == ActionDelete {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// String renders the changes the way terraform plan summarizes them
+func (c *Changes) String() string {
+	if c.Empty() && len(c.Drift) == 0 {
+		return "No changes."
+	}
+	var b strings.Builder
+	if len(c.Drift) > 0 {
+		b.WriteString("Changed outside of terraform:\n")
+		for _, change := range c.Drift {
+			b.WriteString(change.String())
+		}
+	}
+	if !c.Empty() {
+		b.WriteString("Pending changes:\n")
+		for _, change := range c.Resources {
+			b.WriteString(change.String())
+		}
+	}
+	return b.String()
+}
+
+// String renders the change and its attributes, one per line
+func (r ResourceChange) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s (%s)\n", actionSymbols[r.Action], r.Address, r.Action)
+	for _, attr := range r.Attributes {
+		fmt.Fprintf(&b, "    %s\n", attr)
+	}
+	return b.String()
+}
+
+// String renders the attribute as path: before -> after, hiding sensitive values
+func (a AttributeChange) String() string {
+	before, after := render(a.Before), render(a.After)
+	if a.Sensitive {
+		before, after = "(sensitive value)", "(sensitive value)"
+	}
+	if a.Unknown {
+		after = "(known after apply)"
+	}
+	s := fmt.Sprintf("%s: %s -> %s", a.Path, before, after)
+	if a.ForcesReplacement {
+		s += " # forces replacement"
+	}
+	return s
+}
+
+func actionOf(actions []string) string {
+	switch {
+	case len(actions) == 2:
+		return ActionReplace
+	case len(actions) == 1 && actions[0] != "no-op":
+		return actions[0]
+	}
+	return ""
+}
+
+// flatten stores the leaves of value under their paths
+func flatten(prefix string, value interface{}, out map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flatten(path, item, out)
+		}
+	case []interface{}:
+		for i, item := range v {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), item, out)
+		}
+	default:
+		if prefix != "" {
+			out[prefix] = v
+		}
+	}
+}
+
+func joinPath(steps []interface{}) string {
+	var b strings.Builder
+	for _, step := range steps {
+		switch s := step.(type) {
+		case string:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(s)
+		case float64:
+			fmt.Fprintf(&b, "[%d]", int(s))
+		}
+	}
+	return b.String()
+}
+
+func forces(replace []string, path string) bool {
+	for _, p := range replace {
+		if path == p || strings.HasPrefix(path, p+".") || strings.HasPrefix(path, p+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+func render(v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
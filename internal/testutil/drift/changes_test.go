@@ -0,0 +1,80 @@
+package drift
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+func readPlan(t *testing.T) *Changes {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "plan.json"))
+	require.NoError(t, err)
+	changes, err := ParsePlan(data)
+	require.NoError(t, err)
+	return changes
+}
+
+func TestParsePlan(t *testing.T) {
+	t.Parallel()
+
+	changes := readPlan(t)
+	assert.False(t, changes.Empty())
+	require.Len(t, changes.Resources, 2)
+	require.Len(t, changes.Drift, 1)
+
+	apikey, ok := changes.Resource("module.supabase_apikey.supabase_apikey.this[0]")
+	require.True(t, ok)
+	assert.Equal(t, ActionUpdate, apikey.Action)
+	assert.Equal(t, []AttributeChange{{Path: "name", Before: "renamed", After: "ci_key"}}, apikey.Attributes)
+
+	project, ok := changes.Resource("module.supabase_project.supabase_project.this[0]")
+	require.True(t, ok)
+	assert.Equal(t, ActionReplace, project.Action)
+	assert.Equal(t, []AttributeChange{
+		{Path: "id", Before: "abcdefghijklmnopqrst", Unknown: true},
+		{Path: "region", Before: "us-east-1", After: "eu-west-1", ForcesReplacement: true},
+		{Path: "settings.tags[1]", After: "b"},
+	}, project.Attributes)
+	assert.Equal(t, []ResourceChange{project}, changes.Replaced())
+
+	_, ok = changes.Resource("data.supabase_apikeys.this")
+	assert.False(t, ok, "no-op changes are dropped")
+}
+
+func TestChanges_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `Changed outside of terraform:
+~ module.supabase_apikey.supabase_apikey.this[0] (update)
+    name: "ci_key" -> "renamed"
+Pending changes:
+~ module.supabase_apikey.supabase_apikey.this[0] (update)
+    name: "renamed" -> "ci_key"
+-/+ module.supabase_project.supabase_project.this[0] (replace)
+    id: "abcdefghijklmnopqrst" -> (known after apply)
+    region: "us-east-1" -> "eu-west-1" # forces replacement
+    settings.tags[1]: null -> "b"
+`, readPlan(t).String())
+
+	assert.Equal(t, "No changes.", (&Changes{}).String())
+}
+
+func TestAttributeChange_Sensitive(t *testing.T) {
+	t.Parallel()
+
+	attr := AttributeChange{Path: "database_password", Before: "old", After: "new", Sensitive: true}
+	assert.Equal(t, "database_password: (sensitive value) -> (sensitive value)", attr.String())
+}
+
+func TestParsePlan_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParsePlan([]byte("{"))
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+}
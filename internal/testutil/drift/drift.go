@@ -0,0 +1,102 @@
+package drift
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/guard"
+)
+
+// Exit codes of terraform plan -detailed-exitcode
+const (
+	exitNoChanges = 0
+	exitChanges   = 2
+)
+
+// Mutation changes infrastructure behind terraform's back, through the Management API or the mock server
+type Mutation func(t testing.TestingT)
+
+// PlanE re-plans options with -detailed-exitcode and returns the pending changes
+func PlanE(t testing.TestingT, options *terraform.Options) (*Changes, error) {
+	dir, err := os.MkdirTemp("", "terratest-drift-*")
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorUnknown, "create plan directory")
+	}
+	defer os.RemoveAll(dir)
+
+	planOptions, err := options.Clone()
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorUnknown, "clone terraform options")
+	}
+	planOptions.PlanFilePath = filepath.Join(dir, "drift.tfplan")
+
+	code, err := terraform.PlanExitCodeE(t, planOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorUnknown, "terraform plan")
+	}
+	switch code {
+	case exitNoChanges:
+		return &Changes{Resources: []ResourceChange{}, Drift: []ResourceChange{}}, nil
+	case exitChanges:
+	default:
+		return nil, errors.Errorf(errors.ErrorUnknown, "terraform plan exited with %d", code)
+	}
+
+	out, err := terraform.ShowE(t, planOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorUnknown, "terraform show")
+	}
+	return ParsePlan([]byte(out))
+}
+
+// Plan is like PlanE but fails the test on error
+func Plan(t testing.TestingT, options *terraform.Options) *Changes {
+	changes, err := PlanE(t, options)
+	require.NoError(t, err)
+	return changes
+}
+
+// AssertNoDrift re-plans options after an apply and fails with a readable diff when anything would change,
+// which catches perpetual diffs
+func AssertNoDrift(t testing.TestingT, options *terraform.Options) bool {
+	changes := Plan(t, options)
+	return assert.True(t, changes.Empty(), "plan after apply is not empty:\n%s", changes)
+}
+
+// AssertDetectsAndCorrects applies mutate, asserts the next plan notices it, applies again through the guard
+// and asserts the drift is gone. It returns the changes that corrected the drift.
+func AssertDetectsAndCorrects(t testing.TestingT, options *terraform.Options, mutate Mutation) *Changes {
+	mutate(t)
+
+	changes := Plan(t, options)
+	if !assert.False(t, changes.Empty(), "out-of-band change was not detected") {
+		return changes
+	}
+
+	guard.Apply(t, options)
+	AssertNoDrift(t, options)
+	return changes
+}
+
+// RenameAPIKey returns a Mutation renaming the API key id of project ref
+func RenameAPIKey(client *management.Client, ref, id, name string) Mutation {
+	return func(t testing.TestingT) {
+		_, err := client.UpdateAPIKey(context.Background(), ref, id, management.UpdateAPIKey{Name: &name})
+		require.NoError(t, err)
+	}
+}
+
+// DeleteAPIKey returns a Mutation deleting the API key id of project ref
+func DeleteAPIKey(client *management.Client, ref, id string) Mutation {
+	return func(t testing.TestingT) {
+		require.NoError(t, client.DeleteAPIKey(context.Background(), ref, id))
+	}
+}
@@ -0,0 +1,94 @@
+package drift
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	gotesting "github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/mockapi"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/recorder"
+)
+
+// fakeTerraform plans changes while the DRIFT_MARKER file exists, prints DRIFT_PLAN on show and removes
+// the marker on apply
+const fakeTerraform = `#!/bin/sh
+case "$1" in
+  plan) test -f "$DRIFT_MARKER" && exit 2; exit 0 ;;
+  show) cat "$DRIFT_PLAN" ;;
+  apply) rm -f "$DRIFT_MARKER" ;;
+esac
+`
+
+func fakeOptions(t *testing.T) (*terraform.Options, string) {
+	t.Helper()
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "terraform")
+	require.NoError(t, os.WriteFile(binary, []byte(fakeTerraform), 0o700)) //nolint:gosec
+	plan, err := filepath.Abs(filepath.Join("testdata", "plan.json"))
+	require.NoError(t, err)
+
+	marker := filepath.Join(dir, "drifted")
+	return &terraform.Options{
+		TerraformDir:    dir,
+		TerraformBinary: binary,
+		NoColor:         true,
+		Vars:            map[string]interface{}{"organization_id": "hadenlabs", "name": "tftest-drift"},
+		EnvVars:         map[string]string{"DRIFT_MARKER": marker, "DRIFT_PLAN": plan},
+	}, marker
+}
+
+func TestAssertNoDrift(t *testing.T) {
+	t.Parallel()
+
+	options, marker := fakeOptions(t)
+	assert.True(t, AssertNoDrift(t, options))
+
+	require.NoError(t, os.WriteFile(marker, nil, 0o600))
+	changes := Plan(t, options)
+	assert.Len(t, changes.Resources, 2)
+}
+
+func TestAssertDetectsAndCorrects(t *testing.T) {
+	t.Setenv("TF_GUARD_ORGANIZATIONS", "hadenlabs")
+
+	server := mockapi.New()
+	defer server.Close()
+	ref := faker.Project().Ref()
+	server.AddProject(management.Project{ID: ref, OrganizationID: "hadenlabs", Name: faker.Project().TestName()})
+	client := server.Client()
+	key, err := client.CreateAPIKey(context.Background(), ref, management.CreateAPIKey{
+		Type: management.APIKeyTypePublishable,
+		Name: "ci_key",
+	})
+	require.NoError(t, err)
+
+	options, marker := fakeOptions(t)
+	rename := RenameAPIKey(client, ref, key.ID, "renamed")
+	changes := AssertDetectsAndCorrects(t, options, func(t gotesting.TestingT) {
+		rename(t)
+		require.NoError(t, os.WriteFile(marker, nil, 0o600))
+	})
+
+	renamed, err := client.GetAPIKey(context.Background(), ref, key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", renamed.Name)
+	assert.Contains(t, changes.String(), `name: "renamed" -> "ci_key"`)
+	assert.NoFileExists(t, marker)
+}
+
+func TestAssertDetectsAndCorrects_Undetected(t *testing.T) {
+	t.Parallel()
+
+	options, _ := fakeOptions(t)
+	r := recorder.New(t)
+	AssertDetectsAndCorrects(r, options, func(gotesting.TestingT) {})
+	assert.True(t, r.Failed, "a mutation terraform cannot see must fail the test")
+}
@@ -0,0 +1,243 @@
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
+)
+
+const (
+	// Dir is the directory, relative to the test package, holding golden files
+	Dir = "testdata"
+
+	// Ext is the extension of golden files
+	Ext = ".golden"
+
+	// Masked replaces masked values
+	Masked = "<masked>"
+)
+
+// UpdateEnv rewrites golden files instead of comparing against them when set to true
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// Update reports whether UpdateEnv asks to rewrite golden files and checked-in snapshots
+func Update() bool {
+	return config.Must().Golden.Update
+}
+
+// volatilePlanKeys are top-level keys of `terraform show -json` that change between runs
+var volatilePlanKeys = []string{"timestamp", "terraform_version"}
+
+// Option configures masking of a snapshot
+type Option func(*masks)
+
+type masks struct {
+	keys     map[string]bool
+	patterns []pattern
+}
+
+type pattern struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// MaskKeys replaces the strings and numbers held by the given keys, at any depth, with Masked
+func MaskKeys(keys ...string) Option {
+	return func(m *masks) {
+		for _, key := range keys {
+			m.keys[key] = true
+		}
+	}
+}
+
+// MaskPattern replaces every match of re in the rendered snapshot with replacement
+func MaskPattern(re *regexp.Regexp, replacement string) Option {
+	return func(m *masks) {
+		m.patterns = append(m.patterns, pattern{re, replacement})
+	}
+}
+
+func newMasks(opts []Option) *masks {
+	m := &masks{keys: map[string]bool{}}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Path returns the golden file of a snapshot name
+func Path(name string) string {
+	return filepath.Join(Dir, name+Ext)
+}
+
+// Assert compares got with the golden file of name, or rewrites it when Update
+func Assert(t testing.TestingT, name string, got []byte) {
+	path := Path(name)
+	if Update() {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, got, 0o644)) //nolint:gosec
+		return
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run `%s=true go test` to record %s", UpdateEnv, path)
+	assert.Equal(t, string(want), string(got), "snapshot %s changed, run `%s=true go test` if intended", path, UpdateEnv)
+}
+
+// AssertJSON snapshots v as indented JSON with sorted keys after masking
+func AssertJSON(t testing.TestingT, name string, v interface{}, opts ...Option) {
+	data, err := MarshalJSON(v, opts...)
+	require.NoError(t, err)
+	Assert(t, name, data)
+}
+
+// AssertVars snapshots Terraform variables rendered as a terraform.tfvars file after masking
+func AssertVars(t testing.TestingT, name string, vars map[string]interface{}, opts ...Option) {
+	data, err := RenderVars(vars, opts...)
+	require.NoError(t, err)
+	Assert(t, name, data)
+}
+
+// AssertPlanJSON snapshots the output of `terraform show -json` after NormalizePlan
+func AssertPlanJSON(t testing.TestingT, name, plan string, opts ...Option) {
+	data, err := NormalizePlan([]byte(plan), opts...)
+	require.NoError(t, err)
+	Assert(t, name, data)
+}
+
+// AssertPlan runs init, plan and show for options and snapshots the normalized plan
+func AssertPlan(t testing.TestingT, name string, options *terraform.Options, opts ...Option) {
+	clone, err := options.Clone()
+	require.NoError(t, err)
+	if clone.PlanFilePath == "" {
+		dir, err := os.MkdirTemp("", "golden-plan-")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		clone.PlanFilePath = filepath.Join(dir, "plan.out")
+	}
+	AssertPlanJSON(t, name, terraform.InitAndPlanAndShow(t, clone), opts...)
+}
+
+// RenderVars renders variables as a terraform.tfvars file after masking
+func RenderVars(vars map[string]interface{}, opts ...Option) ([]byte, error) {
+	m := newMasks(opts)
+	masked, _ := m.walk(toJSONValue(vars)).(map[string]interface{})
+	data, err := tfvars.Encode(masked)
+	if err != nil {
+		return nil, err
+	}
+	return m.replace(data), nil
+}
+
+// MarshalJSON renders v as indented JSON with sorted keys after masking
+func MarshalJSON(v interface{}, opts ...Option) ([]byte, error) {
+	m := newMasks(opts)
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(m.walk(toJSONValue(v))); err != nil {
+		return nil, errors.Wrap(err, errors.ErrorInvalidArgument, "encode snapshot")
+	}
+	return m.replace(buf.Bytes()), nil
+}
+
+// NormalizePlan drops the run-specific fields of a `terraform show -json` plan, masks it
+// and renders it as indented JSON with sorted keys
+func NormalizePlan(plan []byte, opts ...Option) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(plan))
+	decoder.UseNumber()
+	var v map[string]interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, errors.Wrap(err, errors.ErrorInvalidArgument, "decode plan")
+	}
+	for _, key := range volatilePlanKeys {
+		delete(v, key)
+	}
+	return MarshalJSON(v, opts...)
+}
+
+// toJSONValue converts v into the generic value encoding/json decodes it to, so
+// structs and maps are walked the same way
+func toJSONValue(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var out interface{}
+	if err := decoder.Decode(&out); err != nil {
+		return v
+	}
+	return out
+}
+
+func (m *masks) walk(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		out := make(map[string]interface{}, len(value))
+		for _, key := range keys {
+			if m.keys[key] {
+				out[key] = mask(value[key])
+				continue
+			}
+			out[key] = m.walk(value[key])
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, item := range value {
+			out[i] = m.walk(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// mask replaces strings and numbers in v with Masked, keeping nulls and booleans
+// such as after_sensitive flags visible
+func mask(v interface{}) interface{} {
+	switch value := v.(type) {
+	case string, json.Number:
+		return Masked
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			out[key] = mask(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, item := range value {
+			out[i] = mask(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func (m *masks) replace(data []byte) []byte {
+	for _, p := range m.patterns {
+		data = p.re.ReplaceAll(data, []byte(p.replacement))
+	}
+	return data
+}
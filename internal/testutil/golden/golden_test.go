@@ -0,0 +1,67 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testName = regexp.MustCompile(`tftest-[a-z0-9-]+`)
+
+func TestAssertVars(t *testing.T) {
+	t.Parallel()
+
+	AssertVars(t, "vars", map[string]interface{}{
+		"database_password": "Xy7!kd92LmQp0s#a",
+		"name":              "tftest-backend-9k2jd8s7x2",
+		"organization_id":   "ysidaatusqmwbbblhrtn",
+		"region":            "us-east-1",
+		"instance_size":     "micro",
+		"description":       nil,
+		"module_enabled":    true,
+	}, MaskKeys("database_password"), MaskPattern(testName, "tftest-"+Masked))
+}
+
+func TestAssertPlanJSON(t *testing.T) {
+	t.Parallel()
+
+	plan, err := os.ReadFile(filepath.Join(Dir, "plan.json"))
+	require.NoError(t, err)
+
+	AssertPlanJSON(t, "plan", string(plan), MaskKeys("database_password"), MaskPattern(testName, "tftest-"+Masked))
+}
+
+func TestNormalizePlan(t *testing.T) {
+	t.Parallel()
+
+	got, err := NormalizePlan([]byte(`{"timestamp":"now","terraform_version":"1.9.8","b":{"database_password":null,"a":1.50}}`),
+		MaskKeys("database_password"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `{
+  "b": {
+    "a": 1.50,
+    "database_password": null
+  }
+}
+`, string(got), "null values stay visible and numbers keep their text")
+
+	_, err = NormalizePlan([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestMarshalJSON_Struct(t *testing.T) {
+	t.Parallel()
+
+	got, err := MarshalJSON(struct {
+		Name     string `json:"name"`
+		Password string `json:"database_password"`
+	}{"tftest-api-abc", "secret"}, MaskKeys("database_password"), MaskPattern(testName, "tftest-"+Masked))
+	require.NoError(t, err)
+
+	assert.Equal(t, "{\n  \"database_password\": \"<masked>\",\n  \"name\": \"tftest-<masked>\"\n}\n", string(got))
+}
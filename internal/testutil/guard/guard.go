@@ -0,0 +1,198 @@
+package guard
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
+)
+
+// OverrideEnv disables the guard when set to true
+const OverrideEnv = "TF_GUARD_OVERRIDE"
+
+// OrganizationsEnv lists the allowed organizations, the guard refuses everything while it is empty
+const OrganizationsEnv = "TF_GUARD_ORGANIZATIONS"
+
+// Variables naming the organization and the project of a fixture
+const (
+	OrganizationVar = "organization_id"
+	NameVar         = "name"
+)
+
+// Guard refuses to apply or destroy configurations outside the allowed organizations or whose project
+// name lacks the test prefix, so a misconfigured environment cannot touch real projects
+type Guard struct {
+	Organizations []string
+	Prefix        string
+	Override      bool
+}
+
+// FromConfig returns the guard configured by TF_GUARD_ORGANIZATIONS and TF_GUARD_OVERRIDE, requiring
+// faker.TestNamePrefix
+func FromConfig(conf *config.Config) Guard {
+	return Guard{
+		Organizations: conf.Guard.Organizations,
+		Prefix:        faker.TestNamePrefix,
+		Override:      conf.Guard.Override,
+	}
+}
+
+// Default is FromConfig for the environment
+func Default() Guard {
+	return FromConfig(config.Must())
+}
+
+// Check returns an ErrorPermissionDenied error unless the organization and project name that options
+// pass to terraform, through Vars or var files, are allowed
+func (g Guard) Check(options *terraform.Options) error {
+	if g.Override {
+		return nil
+	}
+	vars, err := Vars(options)
+	if err != nil {
+		return err
+	}
+
+	org, _ := vars[OrganizationVar].(string)
+	if org == "" {
+		return errors.Errorf(errors.ErrorPermissionDenied, "%s sets no %s, the guard cannot tell where it runs, set %s=true to bypass",
+			options.TerraformDir, OrganizationVar, OverrideEnv)
+	}
+	name, _ := vars[NameVar].(string)
+	return g.CheckProject(org, name)
+}
+
+// CheckProject returns an ErrorPermissionDenied error unless the organization org is allowed and the project
+// name starts with the prefix, for projects created through the Management API rather than terraform
+func (g Guard) CheckProject(org, name string) error {
+	if g.Override {
+		return nil
+	}
+	if len(g.Organizations) == 0 {
+		return errors.Errorf(errors.ErrorPermissionDenied, "%s is empty, set it to the organizations tests may use or set %s=true to bypass",
+			OrganizationsEnv, OverrideEnv)
+	}
+	if !contains(g.Organizations, org) {
+		return errors.Errorf(errors.ErrorPermissionDenied, "organization %q is not in the allowlist [%s], set %s=true to bypass",
+			org, strings.Join(g.Organizations, ", "), OverrideEnv)
+	}
+	if !strings.HasPrefix(name, g.Prefix) {
+		return errors.Errorf(errors.ErrorPermissionDenied, "project %q does not start with %q, set %s=true to bypass",
+			name, g.Prefix, OverrideEnv)
+	}
+	return nil
+}
+
+// Require fails the test when Check refuses options
+func (g Guard) Require(t testing.TestingT, options *terraform.Options) {
+	require.NoError(t, g.allow(t, options))
+}
+
+// allow is Check unless the override is set
+func (g Guard) allow(t testing.TestingT, options *terraform.Options) error {
+	if g.Override {
+		logger.Default.Logf(t, "%s is set, not guarding %s", OverrideEnv, options.TerraformDir)
+		return nil
+	}
+	return g.Check(options)
+}
+
+// Apply runs terraform apply once the guard allows options
+func Apply(t testing.TestingT, options *terraform.Options) string {
+	Default().Require(t, options)
+	return terraform.Apply(t, options)
+}
+
+// InitAndApply runs terraform init and apply once the guard allows options
+func InitAndApply(t testing.TestingT, options *terraform.Options) string {
+	Default().Require(t, options)
+	return terraform.InitAndApply(t, options)
+}
+
+// ApplyE runs terraform apply once the guard allows options, returning the refusal or the apply error
+func ApplyE(t testing.TestingT, options *terraform.Options) (string, error) {
+	if err := Default().allow(t, options); err != nil {
+		return "", err
+	}
+	return terraform.ApplyE(t, options)
+}
+
+// InitAndApplyE runs terraform init and apply once the guard allows options, returning the refusal or the
+// apply error
+func InitAndApplyE(t testing.TestingT, options *terraform.Options) (string, error) {
+	if err := Default().allow(t, options); err != nil {
+		return "", err
+	}
+	return terraform.InitAndApplyE(t, options)
+}
+
+// Destroy runs terraform destroy once the guard allows options, meant for defer in place of terraform.Destroy
+func Destroy(t testing.TestingT, options *terraform.Options) string {
+	Default().Require(t, options)
+	return terraform.Destroy(t, options)
+}
+
+// Vars returns the variables terraform would read for options, in its order of precedence: terraform.tfvars,
+// *.auto.tfvars in lexical order, the var files, then Vars
+func Vars(options *terraform.Options) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+	for _, name := range []string{"terraform.tfvars", "terraform.tfvars.json"} {
+		err := merge(vars, filepath.Join(options.TerraformDir, name))
+		if err != nil && !errors.IsKind(err, errors.ErrorNotFound) {
+			return nil, err
+		}
+	}
+
+	files := []string{}
+	for _, pattern := range []string{"*.auto.tfvars", "*.auto.tfvars.json"} {
+		matches, _ := filepath.Glob(filepath.Join(options.TerraformDir, pattern))
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	for _, file := range options.VarFiles {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(options.TerraformDir, file)
+		}
+		files = append(files, file)
+	}
+	for _, file := range files {
+		if err := merge(vars, file); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, value := range options.Vars {
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// merge reads the var file into vars, overriding the values already there
+func merge(vars map[string]interface{}, file string) error {
+	read, err := tfvars.Read(file)
+	if err != nil {
+		return err
+	}
+	for name, value := range read {
+		vars[name] = value
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
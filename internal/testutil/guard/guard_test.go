@@ -0,0 +1,163 @@
+package guard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/recorder"
+)
+
+var testGuard = Guard{Organizations: []string{"hadenlabs", "hadenlabs-ci"}, Prefix: "tftest-"}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		guard   Guard
+		vars    map[string]interface{}
+		allowed bool
+		message string
+	}{
+		{
+			name:    "allowed",
+			guard:   testGuard,
+			vars:    map[string]interface{}{"organization_id": "hadenlabs-ci", "name": "tftest-basic"},
+			allowed: true,
+		},
+		{
+			name:    "organization not allowed",
+			guard:   testGuard,
+			vars:    map[string]interface{}{"organization_id": "acme-prod", "name": "tftest-basic"},
+			message: `organization "acme-prod" is not in the allowlist [hadenlabs, hadenlabs-ci], set TF_GUARD_OVERRIDE=true to bypass`,
+		},
+		{
+			name:    "production name",
+			guard:   testGuard,
+			vars:    map[string]interface{}{"organization_id": "hadenlabs", "name": "billing"},
+			message: `project "billing" does not start with "tftest-", set TF_GUARD_OVERRIDE=true to bypass`,
+		},
+		{
+			name:    "no organization",
+			guard:   testGuard,
+			vars:    map[string]interface{}{"name": "tftest-basic"},
+			message: "sets no organization_id",
+		},
+		{
+			name:    "override",
+			guard:   Guard{Organizations: testGuard.Organizations, Prefix: "tftest-", Override: true},
+			vars:    map[string]interface{}{"organization_id": "acme-prod", "name": "billing"},
+			allowed: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.guard.Check(&terraform.Options{TerraformDir: t.TempDir(), Vars: tc.vars})
+			if tc.allowed {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.IsKind(err, errors.ErrorPermissionDenied), err)
+			assert.Contains(t, err.Error(), tc.message)
+		})
+	}
+}
+
+func TestCheckProject(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, testGuard.CheckProject("hadenlabs", "tftest-basic"))
+	err := testGuard.CheckProject("acme-prod", "tftest-basic")
+	assert.True(t, errors.IsKind(err, errors.ErrorPermissionDenied), err)
+	err = testGuard.CheckProject("hadenlabs", "billing")
+	assert.True(t, errors.IsKind(err, errors.ErrorPermissionDenied), err)
+	assert.NoError(t, Guard{Override: true}.CheckProject("acme-prod", "billing"))
+}
+
+func TestCheck_EmptyAllowlist(t *testing.T) {
+	t.Parallel()
+
+	guard := Guard{Prefix: "tftest-"}
+	err := guard.CheckProject("hadenlabs", "tftest-project")
+	assert.True(t, errors.IsKind(err, errors.ErrorPermissionDenied), "an unset allowlist allows nothing: %v", err)
+
+	guard.Override = true
+	assert.NoError(t, guard.CheckProject("hadenlabs", "tftest-project"))
+}
+
+func TestVars(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	write("terraform.tfvars", "organization_id = \"acme-prod\"\nname = \"billing\"\nregion = \"us-east-1\"\n")
+	write("a.auto.tfvars.json", `{"name": "tftest-auto"}`)
+	write("vars.tfvars", "organization_id = \"hadenlabs\"\n")
+
+	options := &terraform.Options{
+		TerraformDir: dir,
+		VarFiles:     []string{"vars.tfvars"},
+		Vars:         map[string]interface{}{"region": "eu-west-1"},
+	}
+	vars, err := Vars(options)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"organization_id": "hadenlabs",
+		"name":            "tftest-auto",
+		"region":          "eu-west-1",
+	}, vars)
+	assert.NoError(t, testGuard.Check(options), "var files are guarded like Vars")
+
+	options.VarFiles = append(options.VarFiles, "missing.tfvars")
+	_, err = Vars(options)
+	assert.True(t, errors.IsKind(err, errors.ErrorNotFound))
+}
+
+func TestRequire(t *testing.T) {
+	t.Parallel()
+
+	options := &terraform.Options{
+		TerraformDir: t.TempDir(),
+		Vars:         map[string]interface{}{"organization_id": "acme-prod", "name": "billing"},
+	}
+	r := recorder.New(t)
+	testGuard.Require(r, options)
+	assert.True(t, r.Failed, "a production project must fail the test before terraform runs")
+}
+
+func TestApplyE(t *testing.T) {
+	t.Parallel()
+
+	options := &terraform.Options{
+		TerraformDir:    t.TempDir(),
+		TerraformBinary: filepath.Join(t.TempDir(), "terraform"),
+		Vars:            map[string]interface{}{"organization_id": "acme-prod", "name": "billing"},
+	}
+	_, err := ApplyE(t, options)
+	assert.True(t, errors.IsKind(err, errors.ErrorPermissionDenied), "terraform must not run: %v", err)
+	_, err = InitAndApplyE(t, options)
+	assert.True(t, errors.IsKind(err, errors.ErrorPermissionDenied), "terraform must not run: %v", err)
+}
+
+func TestFromConfig(t *testing.T) {
+	t.Parallel()
+
+	conf := config.New()
+	conf.Guard.Organizations = []string{"hadenlabs"}
+	guard := FromConfig(conf)
+	assert.Equal(t, []string{"hadenlabs"}, guard.Organizations)
+	assert.Equal(t, "tftest-", guard.Prefix)
+	assert.False(t, guard.Override)
+}
@@ -0,0 +1,177 @@
+package imports
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/drift"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/guard"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/supabase"
+)
+
+const (
+	// ProjectAddress is the project resource of the project-basic fixture
+	ProjectAddress = "module.supabase_project.supabase_project.this[0]"
+
+	// BlockFile is the file the import block is written to, in the root module
+	BlockFile = "terratest_import.tf"
+)
+
+// Method is how a resource is brought under terraform management
+type Method string
+
+// Import methods
+const (
+	// MethodBlock writes an import {} block and applies it
+	MethodBlock Method = "block"
+
+	// MethodCommand runs terraform import
+	MethodCommand Method = "command"
+)
+
+// Mismatch is an attribute whose imported value differs from the configuration
+type Mismatch struct {
+	Address   string
+	Attribute drift.AttributeChange
+}
+
+// String renders the mismatch as address.path: imported X, configured Y
+func (m Mismatch) String() string {
+	s := fmt.Sprintf("%s.%s: imported %s, configured %s", m.Address, m.Attribute.Path,
+		value(m.Attribute.Before, m.Attribute.Sensitive), value(m.Attribute.After, m.Attribute.Sensitive))
+	if m.Attribute.ForcesReplacement {
+		s += " (forces replacement)"
+	}
+	return s
+}
+
+// Block renders an import block bringing the resource id under the address to
+func Block(to, id string) ([]byte, error) {
+	traversal, diags := hclsyntax.ParseTraversalAbs([]byte(to), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, errors.Wrapf(diags, errors.ErrorInvalidArgument, "address %q", to)
+	}
+	file := hclwrite.NewEmptyFile()
+	body := file.Body().AppendNewBlock("import", nil).Body()
+	body.SetAttributeTraversal("to", traversal)
+	body.SetAttributeValue("id", cty.StringVal(id))
+	return hclwrite.Format(file.Bytes()), nil
+}
+
+// WriteBlock writes the import block for id into the root module at dir, removing it when the test ends
+func WriteBlock(t supabase.CleanupT, dir, to, id string) string {
+	data, err := Block(to, id)
+	require.NoError(t, err)
+	path := filepath.Join(dir, BlockFile)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	t.Cleanup(func() { _ = os.Remove(path) })
+	return path
+}
+
+// Command runs terraform import for id into the address to, once the guard allows options
+func Command(t testing.TestingT, options *terraform.Options, to, id string) {
+	if err := guard.Default().Check(options); err != nil {
+		require.NoError(t, err)
+		return
+	}
+	args := append(terraform.FormatArgs(options, "import", "-input=false"), to, id)
+	terraform.RunTerraformCommand(t, options, args...)
+}
+
+// Mismatches returns the attributes a plan would change after an import, the imported value
+// being the plan's before and the configured value its after. Attributes known only after apply
+// are computed by the provider and never mismatch.
+func Mismatches(changes *drift.Changes) []Mismatch {
+	mismatches := []Mismatch{}
+	for _, change := range changes.Resources {
+		for _, attr := range change.Attributes {
+			if attr.Unknown {
+				continue
+			}
+			mismatches = append(mismatches, Mismatch{Address: change.Address, Attribute: attr})
+		}
+	}
+	return mismatches
+}
+
+// AssertClean imports id into the address to with method, then asserts the follow-up plan is empty and
+// reports every mismatched attribute. It returns the mismatches, none when the import is clean.
+func AssertClean(t supabase.CleanupT, options *terraform.Options, method Method, to, id string) []Mismatch {
+	switch method {
+	case MethodBlock:
+		WriteBlock(t, options.TerraformDir, to, id)
+		terraform.Init(t, options)
+	case MethodCommand:
+		terraform.Init(t, options)
+		Command(t, options, to, id)
+	default:
+		require.Failf(t, "unknown import method", "%q", method)
+	}
+
+	changes := drift.Plan(t, options)
+	mismatches := Mismatches(changes)
+	if method == MethodBlock && len(mismatches) == 0 {
+		// the import block only takes effect on apply
+		guard.Apply(t, options)
+		changes = drift.Plan(t, options)
+		mismatches = Mismatches(changes)
+	}
+
+	lines := make([]string, 0, len(mismatches))
+	for _, mismatch := range mismatches {
+		lines = append(lines, mismatch.String())
+	}
+	assert.Empty(t, lines, "import of %s into %s is not clean:\n%s", id, to, changes)
+	return mismatches
+}
+
+// CreateProject creates project through the Management API, outside of terraform, and deletes it when the
+// test ends unless terraform already destroyed it. The guard must allow the organization and name of project.
+func CreateProject(t supabase.CleanupT, client *management.Client, project *supabase.Project) *management.Project {
+	if err := guard.Default().CheckProject(project.OrganizationID, project.Name); err != nil {
+		require.NoError(t, err)
+		return nil
+	}
+	ctx := context.Background()
+	created, err := client.CreateProject(ctx, management.CreateProject{
+		OrganizationID:      project.OrganizationID,
+		Name:                project.Name,
+		DBPass:              project.DatabasePassword,
+		Region:              project.Region,
+		DesiredInstanceSize: project.InstanceSize,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := client.DeleteProject(ctx, created.ID); err != nil && !errors.IsKind(err, errors.ErrorNotFound) {
+			t.Errorf("delete project %s: %v", created.ID, err)
+		}
+	})
+	return created
+}
+
+func value(v interface{}, sensitive bool) string {
+	if sensitive {
+		return "(sensitive value)"
+	}
+	if v == nil {
+		return "null"
+	}
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return strings.TrimSpace(fmt.Sprint(v))
+}
@@ -0,0 +1,182 @@
+package imports
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/drift"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/mockapi"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/recorder"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/supabase"
+)
+
+// fakeTerraform records the import arguments to IMPORT_ARGS, plans changes when IMPORT_PLAN is set
+// and prints it on show
+const fakeTerraform = `#!/bin/sh
+case "$1" in
+  import) echo "$@" > "$IMPORT_ARGS" ;;
+  plan) test -n "$IMPORT_PLAN" && exit 2; exit 0 ;;
+  show) cat "$IMPORT_PLAN" ;;
+esac
+`
+
+func fakeOptions(t *testing.T, plan string) (*terraform.Options, string) {
+	t.Helper()
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "terraform")
+	require.NoError(t, os.WriteFile(binary, []byte(fakeTerraform), 0o700)) //nolint:gosec
+	if plan != "" {
+		abs, err := filepath.Abs(plan)
+		require.NoError(t, err)
+		plan = abs
+	}
+
+	args := filepath.Join(dir, "import.args")
+	return &terraform.Options{
+		TerraformDir:    dir,
+		TerraformBinary: binary,
+		NoColor:         true,
+		Vars:            map[string]interface{}{"organization_id": "hadenlabs", "name": "tftest-import"},
+		EnvVars:         map[string]string{"IMPORT_ARGS": args, "IMPORT_PLAN": plan},
+	}, args
+}
+
+func TestBlock(t *testing.T) {
+	t.Parallel()
+
+	data, err := Block(ProjectAddress, "abcdefghijklmnopqrst")
+	require.NoError(t, err)
+	assert.Equal(t, `import {
+  to = module.supabase_project.supabase_project.this[0]
+  id = "abcdefghijklmnopqrst"
+}
+`, string(data))
+
+	_, err = Block("supabase_project.", "abcdefghijklmnopqrst")
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+}
+
+func TestWriteBlock(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	var path string
+	t.Run("write", func(t *testing.T) {
+		path = WriteBlock(t, dir, ProjectAddress, "abcdefghijklmnopqrst")
+		assert.Equal(t, filepath.Join(dir, BlockFile), path)
+		assert.FileExists(t, path)
+	})
+	assert.NoFileExists(t, path, "the import block is removed when the test ends")
+}
+
+func TestCommand(t *testing.T) {
+	t.Setenv("TF_GUARD_ORGANIZATIONS", "hadenlabs")
+
+	options, args := fakeOptions(t, "")
+	Command(t, options, ProjectAddress, "abcdefghijklmnopqrst")
+
+	data, err := os.ReadFile(args)
+	require.NoError(t, err)
+	got := strings.Fields(string(data))
+	assert.Equal(t, []string{"import", "-input=false"}, got[:2])
+	assert.Contains(t, got, "-var")
+	assert.Equal(t, []string{ProjectAddress, "abcdefghijklmnopqrst"}, got[len(got)-2:])
+}
+
+func TestCommand_Refused(t *testing.T) {
+	t.Parallel()
+
+	options, args := fakeOptions(t, "")
+	options.Vars["organization_id"] = "acme-prod"
+	r := recorder.New(t)
+	Command(r, options, ProjectAddress, "abcdefghijklmnopqrst")
+	assert.True(t, r.Failed, "the guard refuses an organization outside the allowlist")
+	assert.NoFileExists(t, args, "terraform import does not run")
+}
+
+func TestMismatches(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "plan.json"))
+	require.NoError(t, err)
+	changes, err := drift.ParsePlan(data)
+	require.NoError(t, err)
+
+	mismatches := Mismatches(changes)
+	require.Len(t, mismatches, 2)
+	assert.Equal(t, "module.supabase_project.supabase_project.this[0].database_password: "+
+		"imported (sensitive value), configured (sensitive value)", mismatches[0].String())
+	assert.Equal(t, `module.supabase_project.supabase_project.this[0].region: `+
+		`imported "us-west-1", configured "us-east-1" (forces replacement)`, mismatches[1].String())
+}
+
+func TestAssertClean(t *testing.T) {
+	t.Setenv("TF_GUARD_ORGANIZATIONS", "hadenlabs")
+
+	for _, method := range []Method{MethodBlock, MethodCommand} {
+		method := method
+		t.Run(string(method), func(t *testing.T) {
+			t.Parallel()
+
+			options, _ := fakeOptions(t, "")
+			assert.Empty(t, AssertClean(t, options, method, ProjectAddress, "abcdefghijklmnopqrst"))
+		})
+	}
+}
+
+func TestAssertClean_Mismatch(t *testing.T) {
+	t.Parallel()
+
+	options, _ := fakeOptions(t, filepath.Join("testdata", "plan.json"))
+	r := recorder.New(t)
+	mismatches := AssertClean(r, options, MethodCommand, ProjectAddress, "abcdefghijklmnopqrst")
+	assert.True(t, r.Failed, "a plan after import must be empty")
+	assert.Len(t, mismatches, 2)
+}
+
+func TestCreateProject(t *testing.T) {
+	project := supabase.NewProject()
+	t.Setenv("TF_GUARD_ORGANIZATIONS", project.OrganizationID)
+
+	server := mockapi.New()
+	defer server.Close()
+	client := server.Client()
+
+	var ref string
+	t.Run("create", func(t *testing.T) {
+		created := CreateProject(t, client, project)
+		ref = created.ID
+		assert.Equal(t, project.Name, created.Name)
+		assert.Equal(t, project.Region, created.Region)
+	})
+	_, err := client.GetProject(context.Background(), ref)
+	assert.True(t, errors.IsKind(err, errors.ErrorNotFound), "the project is deleted when the test ends")
+}
+
+func TestCreateProject_Refused(t *testing.T) {
+	t.Parallel()
+
+	server := mockapi.New()
+	defer server.Close()
+
+	cases := map[string]*supabase.Project{
+		"organization not allowed": supabase.NewProject().WithOrganizationID("acme-prod"),
+		"production name":          supabase.NewProject().WithName("billing"),
+	}
+	for name, project := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := recorder.New(t)
+			assert.Nil(t, CreateProject(r, server.Client(), project))
+			assert.True(t, r.Failed)
+		})
+	}
+	assert.Empty(t, server.Projects(), "no project is created outside the allowlist")
+}
@@ -0,0 +1,38 @@
+package ledger
+
+import (
+	"github.com/hadenlabs/terraform-supabase/config"
+	coreledger "github.com/hadenlabs/terraform-supabase/internal/ledger"
+)
+
+// Actions and resource types of a record
+const (
+	ActionCreate  = coreledger.ActionCreate
+	ActionDestroy = coreledger.ActionDestroy
+	TypeProject   = coreledger.TypeProject
+	TypeAPIKey    = coreledger.TypeAPIKey
+)
+
+// Record is a line of the ledger, a resource a test created or destroyed
+type Record = coreledger.Record
+
+// Ledger records the resources terraform creates and destroys in tests on top of the ledger file that the
+// reconcile command reads, kept out of terratest in internal/ledger
+type Ledger struct {
+	*coreledger.Ledger
+}
+
+// New returns a Ledger appending to path on behalf of the run
+func New(path, runID string) *Ledger {
+	return &Ledger{Ledger: coreledger.New(path, runID)}
+}
+
+// FromConfig returns the Ledger configured by TF_LEDGER_PATH and TF_LEDGER_RUN_ID
+func FromConfig(conf *config.Config) *Ledger {
+	return &Ledger{Ledger: coreledger.FromConfig(conf)}
+}
+
+// Default is FromConfig for the environment
+func Default() *Ledger {
+	return FromConfig(config.Must())
+}
@@ -0,0 +1,156 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreledger "github.com/hadenlabs/terraform-supabase/internal/ledger"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/recorder"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/state"
+)
+
+// fakeTerraform copies LEDGER_STATE into the working directory on apply, removes it on destroy and prints
+// it on show, an empty state once destroyed. Apply fails after writing the state while LEDGER_APPLY_FAILS is
+// set, show fails while LEDGER_SHOW_FAILS is set.
+const fakeTerraform = `#!/bin/sh
+case "$1" in
+  apply)
+    cp "$LEDGER_STATE" state.json
+    test -n "$LEDGER_APPLY_FAILS" && exit 1
+    exit 0 ;;
+  destroy) rm -f state.json ;;
+  show)
+    test -n "$LEDGER_SHOW_FAILS" && exit 1
+    if test -f state.json; then cat state.json; else echo '{"format_version":"1.0"}'; fi ;;
+esac
+`
+
+func fakeOptions(t *testing.T) *terraform.Options {
+	t.Helper()
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "terraform")
+	require.NoError(t, os.WriteFile(binary, []byte(fakeTerraform), 0o700)) //nolint:gosec
+	state, err := filepath.Abs(filepath.Join("testdata", "state.json"))
+	require.NoError(t, err)
+
+	return &terraform.Options{
+		TerraformDir:    dir,
+		TerraformBinary: binary,
+		NoColor:         true,
+		Vars:            map[string]interface{}{"organization_id": "hadenlabs", "name": "tftest-ledger"},
+		EnvVars:         map[string]string{"LEDGER_STATE": state},
+	}
+}
+
+func TestResources(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "state.json"))
+	require.NoError(t, err)
+	s, err := state.Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, []Resource{
+		{
+			Address:    "module.supabase_project.supabase_project.this[0]",
+			Type:       TypeProject,
+			ID:         "abcdefghijklmnopqrst",
+			ProjectRef: "abcdefghijklmnopqrst",
+		},
+		{
+			Address:    "module.supabase_apikey.supabase_apikey.this[0]",
+			Type:       TypeAPIKey,
+			ID:         "key-1",
+			ProjectRef: "abcdefghijklmnopqrst",
+		},
+	}, Resources(s), "data sources are not recorded")
+}
+
+func TestInitAndApplyDestroy(t *testing.T) {
+	t.Setenv("TF_GUARD_ORGANIZATIONS", "hadenlabs")
+
+	options := fakeOptions(t)
+	ledger := New(filepath.Join(t.TempDir(), "ledger.jsonl"), "run-1")
+
+	ledger.InitAndApply(t, options)
+	records, err := coreledger.Read(ledger.Path)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, Record{
+		RunID:      "run-1",
+		Test:       t.Name(),
+		Module:     options.TerraformDir,
+		Address:    "module.supabase_project.supabase_project.this[0]",
+		Type:       TypeProject,
+		ID:         "abcdefghijklmnopqrst",
+		ProjectRef: "abcdefghijklmnopqrst",
+		Action:     ActionCreate,
+		Time:       records[0].Time,
+	}, records[0])
+	assert.Len(t, coreledger.Live(records), 2)
+
+	ledger.Destroy(t, options)
+	records, err = coreledger.Read(ledger.Path)
+	require.NoError(t, err)
+	assert.Len(t, records, 4)
+	assert.Empty(t, coreledger.Live(records), "destroyed resources are no longer live")
+}
+
+func TestInitAndApply_Fails(t *testing.T) {
+	t.Setenv("TF_GUARD_ORGANIZATIONS", "hadenlabs")
+
+	options := fakeOptions(t)
+	options.EnvVars["LEDGER_APPLY_FAILS"] = "true"
+	ledger := New(filepath.Join(t.TempDir(), "ledger.jsonl"), "run-1")
+
+	r := recorder.New(t)
+	ledger.InitAndApply(r, options)
+	assert.True(t, r.Failed, "a failed apply fails the test")
+
+	records, err := coreledger.Read(ledger.Path)
+	require.NoError(t, err)
+	assert.Len(t, coreledger.Live(records), 2, "resources created before the apply failed are recorded")
+}
+
+func TestInitAndApply_Refused(t *testing.T) {
+	t.Parallel()
+
+	options := fakeOptions(t)
+	options.Vars["organization_id"] = "acme-prod"
+	ledger := New(filepath.Join(t.TempDir(), "ledger.jsonl"), "run-1")
+
+	r := recorder.New(t)
+	ledger.InitAndApply(r, options)
+	assert.True(t, r.Failed, "a refused apply fails the test")
+	assert.NoFileExists(t, filepath.Join(options.TerraformDir, "state.json"), "terraform does not run")
+	assert.NoFileExists(t, ledger.Path, "nothing is recorded")
+}
+
+func TestDestroy_UnreadableState(t *testing.T) {
+	t.Setenv("TF_GUARD_ORGANIZATIONS", "hadenlabs")
+
+	options := fakeOptions(t)
+	ledger := New(filepath.Join(t.TempDir(), "ledger.jsonl"), "run-1")
+	ledger.InitAndApply(t, options)
+
+	options.EnvVars["LEDGER_SHOW_FAILS"] = "true"
+	r := recorder.New(t)
+	ledger.Destroy(r, options)
+	assert.True(t, r.Failed, "an unreadable state fails the test")
+	assert.NoFileExists(t, filepath.Join(options.TerraformDir, "state.json"), "terraform destroy still runs")
+
+	records, err := coreledger.Read(ledger.Path)
+	require.NoError(t, err)
+	assert.Len(t, coreledger.Live(records), 2, "resources whose destroy was not seen stay live")
+}
+
+func TestModule(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "project/test/project-basic", Module("../../../modules/project/test/project-basic"))
+	assert.Equal(t, "/tmp/project-import", Module("/tmp/project-import"))
+}
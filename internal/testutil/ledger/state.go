@@ -0,0 +1,40 @@
+package ledger
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/state"
+)
+
+// Resource is a managed resource instance of a terraform state
+type Resource struct {
+	Address    string
+	Type       string
+	ID         string
+	ProjectRef string
+}
+
+// Resources returns the managed resources of s, data sources excluded
+func Resources(s *state.State) []Resource {
+	resources := []Resource{}
+	for _, r := range s.Managed() {
+		resource := Resource{Address: r.Address, Type: r.Type}
+		resource.ID, _ = r.Values["id"].(string)
+		resource.ProjectRef, _ = r.Values["project_ref"].(string)
+		if resource.ProjectRef == "" && r.Type == TypeProject {
+			resource.ProjectRef = resource.ID
+		}
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// State returns the managed resources in the state of options
+func State(t testing.TestingT, options *terraform.Options) ([]Resource, error) {
+	s, err := state.ShowE(t, options)
+	if err != nil {
+		return nil, err
+	}
+	return Resources(s), nil
+}
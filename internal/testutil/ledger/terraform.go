@@ -0,0 +1,112 @@
+package ledger
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/guard"
+)
+
+// Apply runs guard.ApplyE and records every resource in the resulting state, even when the apply failed
+// halfway, before failing the test
+func (l *Ledger) Apply(t testing.TestingT, options *terraform.Options) string {
+	out, err := guard.ApplyE(t, options)
+	l.recordApply(t, options, err)
+	return out
+}
+
+// InitAndApply runs guard.InitAndApplyE and records every resource in the resulting state, even when the
+// apply failed halfway, before failing the test
+func (l *Ledger) InitAndApply(t testing.TestingT, options *terraform.Options) string {
+	out, err := guard.InitAndApplyE(t, options)
+	l.recordApply(t, options, err)
+	return out
+}
+
+// recordApply records the state an apply left unless the guard refused it, then fails the test on err
+func (l *Ledger) recordApply(t testing.TestingT, options *terraform.Options, err error) {
+	if !errors.IsKind(err, errors.ErrorPermissionDenied) {
+		l.RecordState(t, options, ActionCreate)
+	}
+	require.NoError(t, err)
+}
+
+// Destroy runs guard.Destroy and records the removal of every resource the state held before, meant for defer
+// in place of guard.Destroy. Resources are only recorded once terraform destroyed them. A state that cannot be
+// read fails the test but never skips the destroy, the resources then stay live in the ledger.
+func (l *Ledger) Destroy(t testing.TestingT, options *terraform.Options) string {
+	resources, err := State(t, options)
+	if err != nil {
+		t.Errorf("ledger: read state before destroy: %v", err)
+	}
+	out := guard.Destroy(t, options)
+	if err == nil {
+		l.RecordResources(t, options, ActionDestroy, resources...)
+	}
+	return out
+}
+
+// Apply records to the ledger configured by the environment
+func Apply(t testing.TestingT, options *terraform.Options) string {
+	return Default().Apply(t, options)
+}
+
+// InitAndApply records to the ledger configured by the environment
+func InitAndApply(t testing.TestingT, options *terraform.Options) string {
+	return Default().InitAndApply(t, options)
+}
+
+// Destroy records to the ledger configured by the environment
+func Destroy(t testing.TestingT, options *terraform.Options) string {
+	return Default().Destroy(t, options)
+}
+
+// RecordState appends a record with action for every resource in the state of options
+func (l *Ledger) RecordState(t testing.TestingT, options *terraform.Options, action string) {
+	resources, err := State(t, options)
+	require.NoError(t, err)
+	l.RecordResources(t, options, action, resources...)
+}
+
+// RecordResources appends a record with action for each of resources, such as a project created through the
+// Management API before terraform imports it
+func (l *Ledger) RecordResources(t testing.TestingT, options *terraform.Options, action string, resources ...Resource) {
+	module := Module(options.TerraformDir)
+	records := make([]Record, 0, len(resources))
+	for _, resource := range resources {
+		records = append(records, Record{
+			Test:       t.Name(),
+			Module:     module,
+			Address:    resource.Address,
+			Type:       resource.Type,
+			ID:         resource.ID,
+			ProjectRef: resource.ProjectRef,
+			Action:     action,
+		})
+	}
+	require.NoError(t, l.Append(records...))
+}
+
+// Module returns the fixture directory relative to modules/, such as project/test/project-basic, or dir
+// itself when it lies outside the repository
+func Module(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+	root, err := modules.Root()
+	if err != nil {
+		return dir
+	}
+	rel, err := filepath.Rel(filepath.Join(root, modules.ModulesDir), abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return dir
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,210 @@
+package matrix
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+// Config lists the versions to combine and where their cached binaries and providers live
+type Config struct {
+	// TerraformVersions are looked up as terraform_<version> in BinDir; none runs the terraform on PATH
+	TerraformVersions []string
+
+	// ProviderVersions maps a provider name to the versions to test, e.g. "supabase": {"1.6.0", "1.7.0"}
+	ProviderVersions map[string][]string
+
+	// BinDir holds the cached terraform binaries
+	BinDir string
+
+	// PluginDir holds the cached providers passed to terraform init -plugin-dir, so runs need no network
+	PluginDir string
+
+	// Report is a file the JSON compatibility report is written to, empty to skip it
+	Report string
+}
+
+// FromConfig builds a Config from the TF_MATRIX_* settings.
+func FromConfig(conf *config.Config) (Config, error) {
+	cfg := Config{
+		TerraformVersions: conf.Matrix.TerraformVersions,
+		ProviderVersions:  map[string][]string{},
+		BinDir:            conf.Matrix.BinDir,
+		PluginDir:         conf.Matrix.PluginDir,
+		Report:            conf.Matrix.Report,
+	}
+	for _, provider := range conf.Matrix.Providers {
+		name, v, ok := strings.Cut(strings.TrimSpace(provider), "@")
+		if !ok || name == "" || v == "" {
+			return cfg, errors.Errorf(errors.ErrorInvalidArgument, "provider %q: want name@version", provider)
+		}
+		cfg.ProviderVersions[name] = append(cfg.ProviderVersions[name], v)
+	}
+	return cfg, nil
+}
+
+// Constraints are the version constraints declared by the versions.tf files of the modules
+type Constraints struct {
+	// Terraform lists every required_version
+	Terraform []string
+
+	// Providers maps a provider name to every version constraint declared for it
+	Providers map[string][]string
+
+	// Sources maps a provider name to its source address
+	Sources map[string]string
+}
+
+// ReadConstraints collects the constraints of every module and test fixture under root/modules.
+func ReadConstraints(root string) (*Constraints, error) {
+	discovered, err := modules.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	constraints := &Constraints{Providers: map[string][]string{}, Sources: map[string]string{}}
+	for _, module := range discovered {
+		constraints.add(module)
+		for _, fixture := range module.Fixtures {
+			constraints.add(fixture)
+		}
+	}
+	return constraints, nil
+}
+
+func (c *Constraints) add(module *modules.Module) {
+	if module.RequiredVersion != "" && !contains(c.Terraform, module.RequiredVersion) {
+		c.Terraform = append(c.Terraform, module.RequiredVersion)
+	}
+	for _, provider := range module.Providers {
+		if provider.Version != "" && !contains(c.Providers[provider.Name], provider.Version) {
+			c.Providers[provider.Name] = append(c.Providers[provider.Name], provider.Version)
+		}
+		if provider.Source != "" {
+			c.Sources[provider.Name] = provider.Source
+		}
+	}
+}
+
+// Allows returns the declared constraints a combination breaks, none when it is within all of them.
+func (c *Constraints) Allows(combination Combination) ([]string, error) {
+	broken := []string{}
+	if combination.Terraform != "" {
+		ok, err := satisfies(combination.Terraform, c.Terraform)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			broken = append(broken, fmt.Sprintf("terraform %s outside %s", combination.Terraform, strings.Join(c.Terraform, ", ")))
+		}
+	}
+	for _, name := range combination.providerNames() {
+		v := combination.Providers[name]
+		ok, err := satisfies(v, c.Providers[name])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			broken = append(broken, fmt.Sprintf("%s %s outside %s", name, v, strings.Join(c.Providers[name], ", ")))
+		}
+	}
+	return broken, nil
+}
+
+// Combination is a Terraform version and a set of provider versions
+type Combination struct {
+	// Terraform is the version of the binary, empty for the terraform on PATH
+	Terraform string
+
+	// Providers maps a provider name to the version the modules are overridden with
+	Providers map[string]string
+}
+
+// Name identifies the combination in subtests and reports, e.g. "terraform-1.9.8/supabase-1.7.0".
+func (c Combination) Name() string {
+	terraform := c.Terraform
+	if terraform == "" {
+		terraform = "default"
+	}
+	parts := []string{"terraform-" + terraform}
+	for _, name := range c.providerNames() {
+		parts = append(parts, name+"-"+c.Providers[name])
+	}
+	return strings.Join(parts, "/")
+}
+
+func (c Combination) providerNames() []string {
+	names := make([]string, 0, len(c.Providers))
+	for name := range c.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Combinations returns the cartesian product of the configured Terraform and provider versions.
+func (c Config) Combinations() []Combination {
+	terraformVersions := c.TerraformVersions
+	if len(terraformVersions) == 0 {
+		terraformVersions = []string{""}
+	}
+	names := make([]string, 0, len(c.ProviderVersions))
+	for name := range c.ProviderVersions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	providerSets := []map[string]string{{}}
+	for _, name := range names {
+		next := []map[string]string{}
+		for _, set := range providerSets {
+			for _, v := range c.ProviderVersions[name] {
+				combined := map[string]string{name: v}
+				for k, existing := range set {
+					combined[k] = existing
+				}
+				next = append(next, combined)
+			}
+		}
+		providerSets = next
+	}
+
+	combinations := []Combination{}
+	for _, terraform := range terraformVersions {
+		for _, providers := range providerSets {
+			combinations = append(combinations, Combination{Terraform: terraform, Providers: providers})
+		}
+	}
+	return combinations
+}
+
+func satisfies(v string, constraints []string) (bool, error) {
+	parsed, err := version.NewVersion(v)
+	if err != nil {
+		return false, errors.Wrapf(err, errors.ErrorInvalidArgument, "version %q", v)
+	}
+	for _, constraint := range constraints {
+		parsedConstraint, err := version.NewConstraint(constraint)
+		if err != nil {
+			return false, errors.Wrapf(err, errors.ErrorInvalidArgument, "constraint %q", constraint)
+		}
+		if !parsedConstraint.Check(parsed) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
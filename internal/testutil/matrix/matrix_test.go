@@ -0,0 +1,145 @@
+package matrix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+func TestFromConfig(t *testing.T) {
+	t.Parallel()
+
+	conf := config.New()
+	conf.Matrix.TerraformVersions = []string{"1.5.7"}
+	conf.Matrix.Providers = []string{"supabase@1.6.0", "supabase@1.7.0", "null@3.2.2"}
+
+	cfg, err := FromConfig(conf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.5.7"}, cfg.TerraformVersions)
+	assert.Equal(t, map[string][]string{"supabase": {"1.6.0", "1.7.0"}, "null": {"3.2.2"}}, cfg.ProviderVersions)
+
+	conf.Matrix.Providers = []string{"supabase"}
+	_, err = FromConfig(conf)
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+}
+
+func TestConfig_Combinations(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{
+		TerraformVersions: []string{"1.5.7", "1.9.8"},
+		ProviderVersions:  map[string][]string{"supabase": {"1.6.0", "1.7.0"}, "null": {"3.2.2"}},
+	}
+
+	names := []string{}
+	for _, combination := range cfg.Combinations() {
+		names = append(names, combination.Name())
+	}
+	assert.Equal(t, []string{
+		"terraform-1.5.7/null-3.2.2/supabase-1.6.0",
+		"terraform-1.5.7/null-3.2.2/supabase-1.7.0",
+		"terraform-1.9.8/null-3.2.2/supabase-1.6.0",
+		"terraform-1.9.8/null-3.2.2/supabase-1.7.0",
+	}, names)
+
+	assert.Equal(t, []Combination{{Providers: map[string]string{}}}, Config{}.Combinations())
+}
+
+func TestReadConstraints(t *testing.T) {
+	t.Parallel()
+
+	constraints, err := ReadConstraints(modules.MustRoot())
+	require.NoError(t, err)
+	assert.Equal(t, []string{">= 1.0.0"}, constraints.Terraform)
+	assert.Equal(t, []string{"1.7.0"}, constraints.Providers["supabase"])
+	assert.Equal(t, "supabase/supabase", constraints.Sources["supabase"])
+
+	outside, err := constraints.Allows(Combination{Terraform: "1.9.8", Providers: map[string]string{"supabase": "1.7.0"}})
+	require.NoError(t, err)
+	assert.Empty(t, outside)
+
+	outside, err = constraints.Allows(Combination{Terraform: "0.15.5", Providers: map[string]string{"supabase": "1.6.0"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"terraform 0.15.5 outside >= 1.0.0", "supabase 1.6.0 outside 1.7.0"}, outside)
+
+	_, err = constraints.Allows(Combination{Terraform: "latest"})
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+}
+
+func TestWorkspace(t *testing.T) {
+	t.Parallel()
+
+	root := modules.MustRoot()
+	constraints, err := ReadConstraints(root)
+	require.NoError(t, err)
+
+	dest := t.TempDir()
+	combination := Combination{Providers: map[string]string{"supabase": "1.6.0"}}
+	require.NoError(t, Workspace(root, dest, combination, constraints))
+
+	for _, dir := range []string{"project", "apikey", "project/test/project-basic"} {
+		data, err := os.ReadFile(filepath.Join(dest, modules.ModulesDir, dir, OverrideFile))
+		require.NoError(t, err, dir)
+		assert.Contains(t, string(data), `version = "1.6.0"`)
+		assert.Contains(t, string(data), `source  = "supabase/supabase"`)
+	}
+
+	overridden, err := modules.Load(filepath.Join(dest, modules.ModulesDir, "project"))
+	require.NoError(t, err)
+	assert.Len(t, overridden.Resources, 1)
+	assert.NoFileExists(t, filepath.Join(dest, modules.ModulesDir, "project", lockFile))
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{
+		TerraformVersions: []string{"1.5.7"},
+		ProviderVersions:  map[string][]string{"supabase": {"1.6.0", "1.7.0"}},
+		BinDir:            t.TempDir(),
+		PluginDir:         t.TempDir(),
+		Report:            filepath.Join(t.TempDir(), "report.json"),
+	}
+	require.NoError(t, os.WriteFile(Binary(cfg.BinDir, "1.5.7"), []byte("#!/bin/sh\n"), 0o700)) //nolint:gosec
+
+	options := &terraform.Options{TerraformDir: filepath.Join(modules.MustRoot(), "modules", "project", "test", "project-basic")}
+	seen := []*terraform.Options{}
+	report := Run(t, cfg, options, func(t *testing.T, options *terraform.Options) {
+		seen = append(seen, options)
+		assert.FileExists(t, filepath.Join(options.TerraformDir, "main.tf"))
+		assert.FileExists(t, filepath.Join(options.TerraformDir, OverrideFile))
+	})
+
+	require.Len(t, seen, 2)
+	assert.Equal(t, Binary(cfg.BinDir, "1.5.7"), seen[0].TerraformBinary)
+	assert.Equal(t, cfg.PluginDir, seen[0].PluginDir)
+	assert.Equal(t, "project/test/project-basic", report.Module)
+	assert.Equal(t, StatusPass, report.Results[0].Status)
+	assert.Equal(t, []string{"supabase 1.6.0 outside 1.7.0"}, report.Results[0].Outside)
+	assert.Len(t, report.Compatible(), 2)
+	assert.FileExists(t, cfg.Report)
+	assert.Contains(t, report.String(), "terraform-1.5.7/supabase-1.7.0  pass")
+}
+
+func TestRun_Uncached(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{TerraformVersions: []string{"1.9.8"}, BinDir: t.TempDir()}
+	options := &terraform.Options{TerraformDir: filepath.Join(modules.MustRoot(), "modules", "project", "test", "project-basic")}
+
+	report := Run(t, cfg, options, func(t *testing.T, _ *terraform.Options) {
+		t.Error("ran without a cached binary")
+	})
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, StatusSkip, report.Results[0].Status)
+	assert.Contains(t, report.Results[0].Reason, "terraform 1.9.8 is not cached")
+	assert.Empty(t, report.Compatible())
+}
@@ -0,0 +1,88 @@
+package matrix
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// Status is the outcome of a combination
+type Status string
+
+// Outcomes of a combination
+const (
+	StatusPass Status = "pass"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+// Result is the outcome of the test for one combination
+type Result struct {
+	Combination Combination `json:"combination"`
+	Name        string      `json:"name"`
+	Status      Status      `json:"status"`
+
+	// Reason explains a skip
+	Reason string `json:"reason,omitempty"`
+
+	// Outside lists the declared constraints the combination breaks
+	Outside []string `json:"outside,omitempty"`
+}
+
+// Report is the compatibility report of a matrix run
+type Report struct {
+	Module  string   `json:"module"`
+	Results []Result `json:"results"`
+}
+
+// Compatible returns the combinations that passed.
+func (r *Report) Compatible() []Combination {
+	combinations := []Combination{}
+	for _, result := range r.Results {
+		if result.Status == StatusPass {
+			combinations = append(combinations, result.Combination)
+		}
+	}
+	return combinations
+}
+
+// String renders the report as an aligned table.
+func (r *Report) String() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "COMBINATION\tSTATUS\tNOTES\n")
+	for _, result := range r.Results {
+		notes := append([]string{}, result.Outside...)
+		if result.Reason != "" {
+			notes = append([]string{result.Reason}, notes...)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.Name, result.Status, strings.Join(notes, "; "))
+	}
+	_ = w.Flush()
+	return fmt.Sprintf("compatibility of %s\n%s", r.Module, b.String())
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteFile writes the report as JSON to path.
+func (r *Report) WriteFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, errors.ErrorUnknown, "create %s", path)
+	}
+	defer file.Close()
+	if err := r.WriteJSON(file); err != nil {
+		return errors.Wrapf(err, errors.ErrorUnknown, "write %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package matrix
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+// Test is a plan-level test run once per combination with options pointing at the overridden copy
+type Test func(t *testing.T, options *terraform.Options)
+
+// Plan is the default Test, it fails when terraform init or plan fails
+func Plan(t *testing.T, options *terraform.Options) {
+	terraform.InitAndPlan(t, options)
+}
+
+// Binary returns the cached terraform binary for version in dir
+func Binary(dir, version string) string {
+	return filepath.Join(dir, "terraform_"+version)
+}
+
+// Run runs test against options.TerraformDir, which must live under modules/, once per combination of cfg
+// as a subtest, and returns the compatibility report. Combinations without a cached terraform binary are skipped.
+func Run(t *testing.T, cfg Config, options *terraform.Options, test Test) *Report {
+	t.Helper()
+
+	root, err := modules.Root()
+	require.NoError(t, err)
+	constraints, err := ReadConstraints(root)
+	require.NoError(t, err)
+
+	dir, err := filepath.Abs(options.TerraformDir)
+	require.NoError(t, err)
+	rel, err := filepath.Rel(filepath.Join(root, modules.ModulesDir), dir)
+	require.NoError(t, err)
+	require.False(t, strings.HasPrefix(rel, ".."), "%s is not under %s", dir, modules.ModulesDir)
+
+	report := &Report{Module: filepath.ToSlash(rel), Results: []Result{}}
+	for _, combination := range cfg.Combinations() {
+		result := Result{Combination: combination, Name: combination.Name()}
+		outside, err := constraints.Allows(combination)
+		require.NoError(t, err)
+		result.Outside = outside
+
+		passed := t.Run(result.Name, func(t *testing.T) {
+			binary := options.TerraformBinary
+			if combination.Terraform != "" {
+				binary = Binary(cfg.BinDir, combination.Terraform)
+				if _, err := os.Stat(binary); err != nil {
+					result.Status = StatusSkip
+					result.Reason = fmt.Sprintf("terraform %s is not cached in %q", combination.Terraform, cfg.BinDir)
+					t.Skip(result.Reason)
+				}
+			}
+
+			workspace := t.TempDir()
+			require.NoError(t, Workspace(root, workspace, combination, constraints))
+
+			combined, err := options.Clone()
+			require.NoError(t, err)
+			combined.TerraformDir = filepath.Join(workspace, modules.ModulesDir, rel)
+			combined.TerraformBinary = binary
+			if cfg.PluginDir != "" {
+				combined.PluginDir = cfg.PluginDir
+			}
+			test(t, combined)
+		})
+		if result.Status == "" {
+			result.Status = StatusFail
+			if passed {
+				result.Status = StatusPass
+			}
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	t.Log(report)
+	if cfg.Report != "" {
+		require.NoError(t, report.WriteFile(cfg.Report))
+	}
+	return report
+}
@@ -0,0 +1,100 @@
+package matrix
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+// OverrideFile is the override file written next to every versions.tf of the copied modules
+const OverrideFile = "matrix_override.tf"
+
+// lockFile pins provider hashes of a single version, so copies drop it
+const lockFile = ".terraform.lock.hcl"
+
+// Workspace copies root/modules into dest, keeping the relative sources between fixtures and modules,
+// and overrides the provider versions of every module declaring them.
+func Workspace(root, dest string, combination Combination, constraints *Constraints) error {
+	src := filepath.Join(root, modules.ModulesDir)
+	err := filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, modules.ModulesDir, rel)
+		if entry.IsDir() {
+			if entry.Name() == ".terraform" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0o755)
+		}
+		if entry.Name() == lockFile || strings.Contains(entry.Name(), ".tfstate") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o600)
+	})
+	if err != nil {
+		return errors.Wrapf(err, errors.ErrorUnknown, "copy %s to %s", src, dest)
+	}
+	if len(combination.Providers) == 0 {
+		return nil
+	}
+
+	discovered, err := modules.Discover(dest)
+	if err != nil {
+		return err
+	}
+	for _, module := range discovered {
+		for _, m := range append([]*modules.Module{module}, module.Fixtures...) {
+			if err := writeOverride(m, combination, constraints); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeOverride pins the providers of combination that module requires.
+func writeOverride(module *modules.Module, combination Combination, constraints *Constraints) error {
+	file := hclwrite.NewEmptyFile()
+	providers := file.Body().AppendNewBlock("terraform", nil).Body().AppendNewBlock("required_providers", nil).Body()
+	overridden := false
+	for _, name := range combination.providerNames() {
+		provider, ok := module.Provider(name)
+		if !ok {
+			continue
+		}
+		source := provider.Source
+		if source == "" {
+			source = constraints.Sources[name]
+		}
+		attrs := map[string]cty.Value{"version": cty.StringVal(combination.Providers[name])}
+		if source != "" {
+			attrs["source"] = cty.StringVal(source)
+		}
+		providers.SetAttributeValue(name, cty.ObjectVal(attrs))
+		overridden = true
+	}
+	if !overridden {
+		return nil
+	}
+	path := filepath.Join(module.Dir, OverrideFile)
+	if err := os.WriteFile(path, hclwrite.Format(file.Bytes()), 0o600); err != nil {
+		return errors.Wrapf(err, errors.ErrorUnknown, "write %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package mirror
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// LockFile is the dependency lock file terraform init writes next to a module
+const LockFile = ".terraform.lock.hcl"
+
+// Lock is a provider entry of a dependency lock file
+type Lock struct {
+	// Address is the provider source address, e.g. registry.terraform.io/supabase/supabase
+	Address string
+	Version string
+	Hashes  []string
+}
+
+// ReadLockFile parses the provider entries of a dependency lock file
+func ReadLockFile(path string) ([]Lock, error) {
+	file, diags := hclparse.NewParser().ParseHCLFile(path)
+	if diags.HasErrors() {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, errors.ErrorNotFound, "lock file %s", path)
+		}
+		return nil, errors.Wrapf(diags, errors.ErrorInvalidArgument, "parse %s", path)
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, errors.Errorf(errors.ErrorInvalidArgument, "unexpected body in %s", path)
+	}
+	locks := []Lock{}
+	for _, block := range body.Blocks {
+		if block.Type != "provider" || len(block.Labels) != 1 {
+			continue
+		}
+		lock := Lock{Address: block.Labels[0]}
+		if attr, ok := block.Body.Attributes["version"]; ok {
+			value, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() || !value.Type().Equals(cty.String) {
+				return nil, errors.Errorf(errors.ErrorInvalidArgument, "version of %s in %s", lock.Address, path)
+			}
+			lock.Version = value.AsString()
+		}
+		if attr, ok := block.Body.Attributes["hashes"]; ok {
+			value, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() || !value.CanIterateElements() {
+				return nil, errors.Errorf(errors.ErrorInvalidArgument, "hashes of %s in %s", lock.Address, path)
+			}
+			for it := value.ElementIterator(); it.Next(); {
+				_, hash := it.Element()
+				if hash.Type().Equals(cty.String) {
+					lock.Hashes = append(lock.Hashes, hash.AsString())
+				}
+			}
+		}
+		locks = append(locks, lock)
+	}
+	return locks, nil
+}
+
+// Verify checks that every provider locked by lockFile is in the mirror at dir, packed or unpacked,
+// with an archive whose h1: or zh: hash is recorded in the lock file
+func Verify(dir, lockFile string) error {
+	locks, err := ReadLockFile(lockFile)
+	if err != nil {
+		return err
+	}
+	problems := []string{}
+	for _, lock := range locks {
+		problems = append(problems, verify(dir, lock)...)
+	}
+	if len(problems) > 0 {
+		return errors.Errorf(errors.ErrorInvalidArgument, "provider mirror %s does not match %s: %s",
+			dir, lockFile, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// RequireVerified fails the test when the mirror at dir does not match lockFile
+func RequireVerified(t testing.TestingT, dir, lockFile string) {
+	require.NoError(t, Verify(dir, lockFile))
+}
+
+func verify(dir string, lock Lock) []string {
+	providerDir := filepath.Join(dir, filepath.FromSlash(lock.Address))
+	parts := strings.Split(lock.Address, "/")
+	name := parts[len(parts)-1]
+
+	archives, _ := filepath.Glob(filepath.Join(providerDir, fmt.Sprintf("terraform-provider-%s_%s_*.zip", name, lock.Version)))
+	unpacked, _ := filepath.Glob(filepath.Join(providerDir, lock.Version, "*_*"))
+	if len(archives)+len(unpacked) == 0 {
+		return []string{fmt.Sprintf("%s %s is not mirrored", lock.Address, lock.Version)}
+	}
+
+	problems := []string{}
+	for _, archive := range archives {
+		h1, err := HashZip(archive)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		zh, err := hashFile(archive)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if !contains(lock.Hashes, h1) && !contains(lock.Hashes, zh) {
+			problems = append(problems, fmt.Sprintf("%s: hash %s not in lock file", archive, h1))
+		}
+	}
+	for _, platformDir := range unpacked {
+		h1, err := HashDir(platformDir)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if !contains(lock.Hashes, h1) {
+			problems = append(problems, fmt.Sprintf("%s: hash %s not in lock file", platformDir, h1))
+		}
+	}
+	return problems
+}
+
+// HashZip returns the h1: hash of the files in a provider archive, as recorded in lock files
+func HashZip(path string) (string, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return "", errors.Wrapf(err, errors.ErrorInvalidArgument, "open %s", path)
+	}
+	defer reader.Close()
+
+	files := map[string]func() (io.ReadCloser, error){}
+	for _, file := range reader.File {
+		if !file.FileInfo().IsDir() {
+			files[file.Name] = file.Open
+		}
+	}
+	return hash1(files)
+}
+
+// HashDir returns the h1: hash of the files of an unpacked provider
+func HashDir(dir string) (string, error) {
+	files := map[string]func() (io.ReadCloser, error){}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = func() (io.ReadCloser, error) { return os.Open(path) }
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, errors.ErrorInvalidArgument, "walk %s", dir)
+	}
+	return hash1(files)
+}
+
+// hash1 is the dirhash "h1:" scheme: the base64 SHA-256 of the sorted "sha256  name" lines of the files
+func hash1(files map[string]func() (io.ReadCloser, error)) (string, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	summary := sha256.New()
+	for _, name := range names {
+		file, err := files[name]()
+		if err != nil {
+			return "", errors.Wrapf(err, errors.ErrorUnknown, "open %s", name)
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, file)
+		file.Close()
+		if err != nil {
+			return "", errors.Wrapf(err, errors.ErrorUnknown, "read %s", name)
+		}
+		fmt.Fprintf(summary, "%x  %s\n", h.Sum(nil), name)
+	}
+	return "h1:" + base64.StdEncoding.EncodeToString(summary.Sum(nil)), nil
+}
+
+// hashFile returns the zh: hash of a provider archive, the hex SHA-256 of the archive itself
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrapf(err, errors.ErrorInvalidArgument, "open %s", path)
+	}
+	defer file.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", errors.Wrapf(err, errors.ErrorUnknown, "read %s", path)
+	}
+	return "zh:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
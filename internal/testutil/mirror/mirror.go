@@ -0,0 +1,93 @@
+package mirror
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+const (
+	// CLIConfigEnv points terraform at a CLI configuration file
+	CLIConfigEnv = "TF_CLI_CONFIG_FILE"
+
+	// cacheDir is the directory, under the temporary directory, holding the generated CLI configurations
+	cacheDir = "terraform-supabase"
+)
+
+// CLIConfig renders a CLI configuration installing every provider from the filesystem mirror at dir
+// and never from the network
+func CLIConfig(dir string) ([]byte, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.ErrorInvalidArgument, "resolve %s", dir)
+	}
+	file := hclwrite.NewEmptyFile()
+	installation := file.Body().AppendNewBlock("provider_installation", nil).Body()
+	installation.AppendNewBlock("filesystem_mirror", nil).Body().SetAttributeValue("path", cty.StringVal(filepath.ToSlash(abs)))
+	return hclwrite.Format(file.Bytes()), nil
+}
+
+// CLIConfigFile writes the CLI configuration for the mirror at dir and returns its path. The file is named
+// after the mirror, so options built for the same mirror share it.
+func CLIConfigFile(dir string) (string, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", errors.Wrapf(err, errors.ErrorNotFound, "provider mirror %s", dir)
+	}
+	if !info.IsDir() {
+		return "", errors.Errorf(errors.ErrorInvalidArgument, "provider mirror %s is not a directory", dir)
+	}
+	data, err := CLIConfig(dir)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	path := filepath.Join(os.TempDir(), cacheDir, "mirror-"+hex.EncodeToString(sum[:8])+".tfrc")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", errors.Wrapf(err, errors.ErrorUnknown, "create %s", filepath.Dir(path))
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", errors.Wrapf(err, errors.ErrorUnknown, "write %s", path)
+	}
+	return path, nil
+}
+
+// Options returns a copy of options installing providers from the mirror at dir
+func Options(options *terraform.Options, dir string) (*terraform.Options, error) {
+	path, err := CLIConfigFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	clone, err := options.Clone()
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorUnknown, "clone terraform options")
+	}
+	if clone.EnvVars == nil {
+		clone.EnvVars = map[string]string{}
+	}
+	clone.EnvVars[CLIConfigEnv] = path
+	return clone, nil
+}
+
+// Build fills the mirror at dir with the providers required by options.TerraformDir for the given platforms,
+// e.g. linux_amd64, with terraform providers mirror. It is the only step reaching the registry.
+func Build(t testing.TestingT, options *terraform.Options, dir string, platforms ...string) {
+	abs, err := filepath.Abs(dir)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(abs, 0o755))
+
+	args := []string{"providers", "mirror"}
+	for _, platform := range platforms {
+		args = append(args, "-platform="+platform)
+	}
+	terraform.RunTerraformCommand(t, options, append(args, abs)...)
+}
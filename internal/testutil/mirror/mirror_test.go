@@ -0,0 +1,159 @@
+package mirror
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+const address = "registry.terraform.io/supabase/supabase"
+
+func TestCLIConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	data, err := CLIConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("provider_installation {\n  filesystem_mirror {\n    path = %q\n  }\n}\n", filepath.ToSlash(dir)), string(data))
+}
+
+func TestCLIConfigFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path, err := CLIConfigFile(dir)
+	require.NoError(t, err)
+	again, err := CLIConfigFile(dir)
+	require.NoError(t, err)
+	assert.Equal(t, path, again)
+	assert.Contains(t, readFile(t, path), filepath.ToSlash(dir))
+
+	_, err = CLIConfigFile(filepath.Join(dir, "missing"))
+	assert.True(t, errors.IsKind(err, errors.ErrorNotFound))
+}
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	original := &terraform.Options{TerraformDir: "project-basic", EnvVars: map[string]string{"TF_LOG": "INFO"}}
+	options, err := Options(original, t.TempDir())
+	require.NoError(t, err)
+
+	assert.FileExists(t, options.EnvVars[CLIConfigEnv])
+	assert.Equal(t, "INFO", options.EnvVars["TF_LOG"])
+	assert.NotContains(t, original.EnvVars, CLIConfigEnv)
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	providerDir := filepath.Join(dir, filepath.FromSlash(address))
+	require.NoError(t, os.MkdirAll(filepath.Join(providerDir, "1.7.0", "darwin_arm64"), 0o755))
+
+	archive := filepath.Join(providerDir, "terraform-provider-supabase_1.7.0_linux_amd64.zip")
+	writeZip(t, archive, map[string]string{"terraform-provider-supabase_v1.7.0": "linux binary"})
+	require.NoError(t, os.WriteFile(filepath.Join(providerDir, "1.7.0", "darwin_arm64", "terraform-provider-supabase_v1.7.0"),
+		[]byte("darwin binary"), 0o600))
+
+	zipHash, err := HashZip(archive)
+	require.NoError(t, err)
+	zh, err := hashFile(archive)
+	require.NoError(t, err)
+	dirHash, err := HashDir(filepath.Join(providerDir, "1.7.0", "darwin_arm64"))
+	require.NoError(t, err)
+
+	t.Run("match", func(t *testing.T) {
+		t.Parallel()
+		lockFile := writeLockFile(t, "1.7.0", zh, dirHash)
+		assert.NoError(t, Verify(dir, lockFile))
+
+		locks, err := ReadLockFile(lockFile)
+		require.NoError(t, err)
+		assert.Equal(t, []Lock{{Address: address, Version: "1.7.0", Hashes: []string{zh, dirHash}}}, locks)
+	})
+
+	t.Run("h1", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, Verify(dir, writeLockFile(t, "1.7.0", zipHash, dirHash)))
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		t.Parallel()
+		err := Verify(dir, writeLockFile(t, "1.7.0", zipHash))
+		require.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+		assert.Contains(t, err.Error(), "darwin_arm64: hash "+dirHash+" not in lock file")
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		t.Parallel()
+		err := Verify(dir, writeLockFile(t, "1.6.0", zipHash))
+		assert.Contains(t, err.Error(), address+" 1.6.0 is not mirrored")
+	})
+
+	t.Run("missing lock file", func(t *testing.T) {
+		t.Parallel()
+		err := Verify(dir, filepath.Join(t.TempDir(), LockFile))
+		assert.True(t, errors.IsKind(err, errors.ErrorNotFound))
+	})
+}
+
+func TestHash1(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "docs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docs", "README"), []byte("readme"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "binary"), []byte("binary"), 0o600))
+	archive := filepath.Join(t.TempDir(), "provider.zip")
+	writeZip(t, archive, map[string]string{"binary": "binary", "docs/README": "readme"})
+
+	fromDir, err := HashDir(dir)
+	require.NoError(t, err)
+	fromZip, err := HashZip(archive)
+	require.NoError(t, err)
+	assert.Equal(t, fromDir, fromZip)
+	assert.Regexp(t, `^h1:[A-Za-z0-9+/]{43}=$`, fromDir)
+}
+
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	w := zip.NewWriter(file)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+}
+
+func writeLockFile(t *testing.T, version string, hashes ...string) string {
+	t.Helper()
+	quoted := ""
+	for _, hash := range hashes {
+		quoted += fmt.Sprintf("    %q,\n", hash)
+	}
+	content := fmt.Sprintf("provider %q {\n  version = %q\n  hashes = [\n%s  ]\n}\n", address, version, quoted)
+	path := filepath.Join(t.TempDir(), LockFile)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,309 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lithammer/shortuuid/v3"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+)
+
+// Token is the access token the mock server accepts.
+const Token = "sbp_mockapi"
+
+// Server is an in-memory stand-in for the Supabase Management API.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	organizations map[string]management.Organization
+	projects      map[string]management.Project
+	apiKeys       map[string][]management.APIKey
+}
+
+// New starts a mock Management API server. Close it when done.
+func New() *Server {
+	s := &Server{
+		organizations: map[string]management.Organization{},
+		projects:      map[string]management.Project{},
+		apiKeys:       map[string][]management.APIKey{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/organizations/{slug}", s.getOrganization)
+	mux.HandleFunc("GET /v1/projects", s.listProjects)
+	mux.HandleFunc("POST /v1/projects", s.createProject)
+	mux.HandleFunc("GET /v1/projects/{ref}", s.getProject)
+	mux.HandleFunc("DELETE /v1/projects/{ref}", s.deleteProject)
+	mux.HandleFunc("GET /v1/projects/{ref}/api-keys", s.listAPIKeys)
+	mux.HandleFunc("POST /v1/projects/{ref}/api-keys", s.createAPIKey)
+	mux.HandleFunc("GET /v1/projects/{ref}/api-keys/{id}", s.getAPIKey)
+	mux.HandleFunc("PATCH /v1/projects/{ref}/api-keys/{id}", s.updateAPIKey)
+	mux.HandleFunc("DELETE /v1/projects/{ref}/api-keys/{id}", s.deleteAPIKey)
+	s.Server = httptest.NewServer(s.authenticate(mux))
+	return s
+}
+
+// Client returns a Management API client pointed at the server.
+func (s *Server) Client() *management.Client {
+	return management.New(s.URL, Token)
+}
+
+// AddOrganization stores an organization as if it existed in the account.
+func (s *Server) AddOrganization(organization management.Organization) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.organizations[organization.ID] = organization
+}
+
+// AddProject stores a project as if it had been created through the API, together
+// with its legacy anon and service_role keys signed with faker.TestJWTSecret.
+func (s *Server) AddProject(project management.Project) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projects[project.ID] = project
+	s.apiKeys[project.ID] = []management.APIKey{
+		{ID: faker.RoleAnon, Name: faker.RoleAnon, Type: management.APIKeyTypeLegacy, APIKey: faker.LegacyJWT(faker.RoleAnon, project.ID)},
+		{ID: faker.RoleServiceRole, Name: faker.RoleServiceRole, Type: management.APIKeyTypeLegacy, APIKey: faker.LegacyJWT(faker.RoleServiceRole, project.ID)},
+	}
+}
+
+// APIKeys returns the API keys of a project.
+func (s *Server) APIKeys(ref string) []management.APIKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]management.APIKey{}, s.apiKeys[ref]...)
+}
+
+// Projects returns the stored projects sorted by reference.
+func (s *Server) Projects() []management.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	projects := make([]management.Project, 0, len(s.projects))
+	for _, project := range s.projects {
+		projects = append(projects, project)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].ID < projects[j].ID })
+	return projects
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			writeError(w, http.StatusUnauthorized, "invalid access token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) getOrganization(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	organization, ok := s.organizations[r.PathValue("slug")]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "organization not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, organization)
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.Projects())
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	project, ok := s.projects[r.PathValue("ref")]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "project not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	in := management.CreateProject{}
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if in.OrganizationID == "" || in.Name == "" || in.DBPass == "" || in.Region == "" {
+		writeError(w, http.StatusBadRequest, "organization_id, name, db_pass and region are required")
+		return
+	}
+	project := management.Project{
+		ID:             faker.Project().Ref(),
+		OrganizationID: in.OrganizationID,
+		Name:           in.Name,
+		Region:         in.Region,
+		Status:         "ACTIVE_HEALTHY",
+		CreatedAt:      time.Now().UTC(),
+	}
+	s.AddProject(project)
+	writeJSON(w, http.StatusCreated, project)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ref := r.PathValue("ref")
+	project, ok := s.projects[ref]
+	if !ok {
+		writeError(w, http.StatusNotFound, "project not found")
+		return
+	}
+	delete(s.projects, ref)
+	delete(s.apiKeys, ref)
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) listAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ref := r.PathValue("ref")
+	s.mu.Lock()
+	_, ok := s.projects[ref]
+	keys := append([]management.APIKey{}, s.apiKeys[ref]...)
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "project not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, keys)
+}
+
+func (s *Server) getAPIKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range s.apiKeys[r.PathValue("ref")] {
+		if key.ID == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, key)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "api key not found")
+}
+
+func (s *Server) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	in := management.CreateAPIKey{}
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if in.Type != management.APIKeyTypePublishable && in.Type != management.APIKeyTypeSecret {
+		writeError(w, http.StatusBadRequest, "type must be publishable or secret")
+		return
+	}
+	if !faker.ValidAPIKeyName(in.Name) {
+		writeError(w, http.StatusBadRequest, "invalid api key name")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ref := r.PathValue("ref")
+	if _, ok := s.projects[ref]; !ok {
+		writeError(w, http.StatusNotFound, "project not found")
+		return
+	}
+	for _, key := range s.apiKeys[ref] {
+		if key.Name == in.Name {
+			writeError(w, http.StatusConflict, "api key name already in use")
+			return
+		}
+	}
+	key := management.APIKey{
+		ID:                shortuuid.New(),
+		Name:              in.Name,
+		Type:              in.Type,
+		Description:       in.Description,
+		APIKey:            faker.APIKeyValue(in.Type),
+		SecretJWTTemplate: in.SecretJWTTemplate,
+	}
+	s.apiKeys[ref] = append(s.apiKeys[ref], key)
+	writeJSON(w, http.StatusCreated, key)
+}
+
+func (s *Server) updateAPIKey(w http.ResponseWriter, r *http.Request) {
+	in := management.UpdateAPIKey{}
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if in.Name != nil && !faker.ValidAPIKeyName(*in.Name) {
+		writeError(w, http.StatusBadRequest, "invalid api key name")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := s.apiKeys[r.PathValue("ref")]
+	i := indexAPIKey(keys, r.PathValue("id"))
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "api key not found")
+		return
+	}
+	if keys[i].Type == management.APIKeyTypeLegacy {
+		writeError(w, http.StatusBadRequest, "legacy api keys cannot be updated")
+		return
+	}
+	if in.Name != nil {
+		for _, key := range keys {
+			if key.Name == *in.Name && key.ID != keys[i].ID {
+				writeError(w, http.StatusConflict, "api key name already in use")
+				return
+			}
+		}
+		keys[i].Name = *in.Name
+	}
+	if in.Description != nil {
+		keys[i].Description = in.Description
+	}
+	if in.SecretJWTTemplate != nil {
+		keys[i].SecretJWTTemplate = in.SecretJWTTemplate
+	}
+	writeJSON(w, http.StatusOK, keys[i])
+}
+
+func (s *Server) deleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ref := r.PathValue("ref")
+	keys := s.apiKeys[ref]
+	i := indexAPIKey(keys, r.PathValue("id"))
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "api key not found")
+		return
+	}
+	if keys[i].Type == management.APIKeyTypeLegacy {
+		writeError(w, http.StatusBadRequest, "legacy api keys cannot be deleted")
+		return
+	}
+	key := keys[i]
+	s.apiKeys[ref] = append(keys[:i:i], keys[i+1:]...)
+	writeJSON(w, http.StatusOK, key)
+}
+
+func indexAPIKey(keys []management.APIKey, id string) int {
+	for i, key := range keys {
+		if key.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
@@ -0,0 +1,118 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+)
+
+// do sends a request to the server and returns the status and the decoded body
+func do(t *testing.T, s *Server, token, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	req, err := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var out interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	decoded, _ := out.(map[string]interface{})
+	return resp.StatusCode, decoded
+}
+
+func TestServer_Routes(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	t.Cleanup(s.Close)
+	s.AddOrganization(management.Organization{ID: "hadenlabs", Plan: management.PlanFree})
+	s.AddProject(management.Project{ID: "abcdefghijklmnopqrst", OrganizationID: "hadenlabs", Name: "tftest-mock"})
+
+	cases := []struct {
+		name    string
+		token   string
+		method  string
+		path    string
+		body    string
+		status  int
+		message string
+	}{
+		{name: "no token", method: http.MethodGet, path: "/v1/projects", status: http.StatusUnauthorized, message: "invalid access token"},
+		{name: "wrong token", token: "sbp_other", method: http.MethodGet, path: "/v1/projects", status: http.StatusUnauthorized, message: "invalid access token"},
+		{name: "get organization", token: Token, method: http.MethodGet, path: "/v1/organizations/hadenlabs", status: http.StatusOK},
+		{name: "unknown organization", token: Token, method: http.MethodGet, path: "/v1/organizations/acme", status: http.StatusNotFound, message: "organization not found"},
+		{name: "get project", token: Token, method: http.MethodGet, path: "/v1/projects/abcdefghijklmnopqrst", status: http.StatusOK},
+		{name: "unknown project", token: Token, method: http.MethodGet, path: "/v1/projects/missing", status: http.StatusNotFound, message: "project not found"},
+		{name: "invalid project body", token: Token, method: http.MethodPost, path: "/v1/projects", body: "{", status: http.StatusBadRequest},
+		{name: "incomplete project", token: Token, method: http.MethodPost, path: "/v1/projects", body: `{"name":"tftest-mock"}`, status: http.StatusBadRequest, message: "organization_id, name, db_pass and region are required"},
+		{name: "delete unknown project", token: Token, method: http.MethodDelete, path: "/v1/projects/missing", status: http.StatusNotFound, message: "project not found"},
+		{name: "keys of unknown project", token: Token, method: http.MethodGet, path: "/v1/projects/missing/api-keys", status: http.StatusNotFound, message: "project not found"},
+		{name: "unknown key", token: Token, method: http.MethodGet, path: "/v1/projects/abcdefghijklmnopqrst/api-keys/missing", status: http.StatusNotFound, message: "api key not found"},
+		{name: "legacy key type", token: Token, method: http.MethodPost, path: "/v1/projects/abcdefghijklmnopqrst/api-keys", body: `{"type":"legacy","name":"ci_key"}`, status: http.StatusBadRequest, message: "type must be publishable or secret"},
+		{name: "invalid key name", token: Token, method: http.MethodPost, path: "/v1/projects/abcdefghijklmnopqrst/api-keys", body: `{"type":"secret","name":"ci key!"}`, status: http.StatusBadRequest, message: "invalid api key name"},
+		{name: "key of unknown project", token: Token, method: http.MethodPost, path: "/v1/projects/missing/api-keys", body: `{"type":"secret","name":"ci_key"}`, status: http.StatusNotFound, message: "project not found"},
+		{name: "update legacy key", token: Token, method: http.MethodPatch, path: "/v1/projects/abcdefghijklmnopqrst/api-keys/anon", body: `{"name":"renamed"}`, status: http.StatusBadRequest, message: "legacy api keys cannot be updated"},
+		{name: "delete legacy key", token: Token, method: http.MethodDelete, path: "/v1/projects/abcdefghijklmnopqrst/api-keys/anon", status: http.StatusBadRequest, message: "legacy api keys cannot be deleted"},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status, body := do(t, s, tc.token, tc.method, tc.path, tc.body)
+			assert.Equal(t, tc.status, status)
+			if tc.message != "" {
+				assert.Equal(t, tc.message, body["message"])
+			}
+		})
+	}
+}
+
+func TestServer_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	defer s.Close()
+
+	status, project := do(t, s, Token, http.MethodPost, "/v1/projects",
+		`{"organization_id":"hadenlabs","name":"tftest-mock","db_pass":"s3cr3t","region":"us-east-1"}`)
+	require.Equal(t, http.StatusCreated, status)
+	ref, _ := project["id"].(string)
+	require.NotEmpty(t, ref)
+	assert.Equal(t, "ACTIVE_HEALTHY", project["status"])
+	require.Len(t, s.APIKeys(ref), 2, "projects come with the legacy anon and service_role keys")
+
+	status, key := do(t, s, Token, http.MethodPost, "/v1/projects/"+ref+"/api-keys", `{"type":"secret","name":"ci_key"}`)
+	require.Equal(t, http.StatusCreated, status)
+	id, _ := key["id"].(string)
+	assert.NotEmpty(t, key["api_key"])
+
+	status, body := do(t, s, Token, http.MethodPost, "/v1/projects/"+ref+"/api-keys", `{"type":"publishable","name":"ci_key"}`)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "api key name already in use", body["message"])
+
+	status, key = do(t, s, Token, http.MethodPatch, "/v1/projects/"+ref+"/api-keys/"+id, `{"name":"renamed","description":"rotated"}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "renamed", key["name"])
+	assert.Equal(t, "rotated", key["description"])
+
+	status, _ = do(t, s, Token, http.MethodDelete, "/v1/projects/"+ref+"/api-keys/"+id, "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, s.APIKeys(ref), 2)
+
+	status, _ = do(t, s, Token, http.MethodDelete, "/v1/projects/"+ref, "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, s.Projects())
+	assert.Empty(t, s.APIKeys(ref), "the keys go with the project")
+}
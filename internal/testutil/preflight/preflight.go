@@ -0,0 +1,187 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/guard"
+)
+
+// InstanceSizeVar is the variable of the requested instance size
+const InstanceSizeVar = "instance_size"
+
+// Limits are the quotas of an organization plan, zero is unlimited
+type Limits struct {
+	ActiveProjects int
+}
+
+// PlanLimits are the limits of each organization plan
+var PlanLimits = map[string]Limits{
+	management.PlanFree: {ActiveProjects: 2},
+}
+
+// inactiveStatuses are the project statuses that do not count against the active projects limit
+var inactiveStatuses = map[string]bool{
+	"INACTIVE":    true,
+	"PAUSING":     true,
+	"GOING_DOWN":  true,
+	"REMOVED":     true,
+	"INIT_FAILED": true,
+}
+
+// Budget is what tests are allowed to provision
+type Budget struct {
+	// InstanceSizes are the allowed instance sizes, any size when empty
+	InstanceSizes []string
+
+	// MaxProjects caps the active projects of the organization, no cap when zero
+	MaxProjects int
+
+	// Fail fails the test over budget instead of skipping it
+	Fail bool
+}
+
+// FromConfig returns the budget configured by the TF_PREFLIGHT_* variables
+func FromConfig(conf *config.Config) Budget {
+	return Budget{
+		InstanceSizes: conf.Preflight.InstanceSizes,
+		MaxProjects:   conf.Preflight.MaxProjects,
+		Fail:          conf.Preflight.Fail,
+	}
+}
+
+// Request is what a test is about to provision
+type Request struct {
+	OrganizationID string
+	InstanceSize   string
+	Projects       int
+}
+
+// RequestFor returns the request of a fixture creating one project from the variables of options
+func RequestFor(options *terraform.Options) (Request, error) {
+	vars, err := guard.Vars(options)
+	if err != nil {
+		return Request{}, err
+	}
+	request := Request{Projects: 1}
+	request.OrganizationID, _ = vars[guard.OrganizationVar].(string)
+	request.InstanceSize, _ = vars[InstanceSizeVar].(string)
+	return request, nil
+}
+
+// Checker compares requests with a budget and with the plan limits of the organization
+type Checker struct {
+	Client *management.Client
+	Budget Budget
+}
+
+// New returns a Checker asking client for organizations and projects
+func New(client *management.Client, budget Budget) *Checker {
+	return &Checker{Client: client, Budget: budget}
+}
+
+// Default returns the Checker configured by the environment
+func Default() *Checker {
+	conf := config.Must()
+	return New(management.NewFromConfig(conf), FromConfig(conf))
+}
+
+// Check returns an ErrorResourceExhausted error when request is over the budget or the plan limits,
+// before terraform gets halfway through creating it
+func (c *Checker) Check(ctx context.Context, request Request) error {
+	if request.InstanceSize != "" && len(c.Budget.InstanceSizes) > 0 && !contains(c.Budget.InstanceSizes, request.InstanceSize) {
+		return errors.Errorf(errors.ErrorResourceExhausted, "instance size %q is over budget [%s], add it to TF_PREFLIGHT_INSTANCE_SIZES",
+			request.InstanceSize, strings.Join(c.Budget.InstanceSizes, ", "))
+	}
+	if request.Projects == 0 {
+		return nil
+	}
+
+	organization, err := c.Client.GetOrganization(ctx, request.OrganizationID)
+	if err != nil {
+		return apiError(err)
+	}
+	active, err := c.ActiveProjects(ctx, request.OrganizationID)
+	if err != nil {
+		return apiError(err)
+	}
+	after := active + request.Projects
+	if limit := PlanLimits[organization.Plan].ActiveProjects; limit > 0 && after > limit {
+		return errors.Errorf(errors.ErrorResourceExhausted,
+			"organization %s on the %s plan has %d of %d active projects, creating %d more exceeds the plan limit",
+			organization.ID, organization.Plan, active, limit, request.Projects)
+	}
+	if c.Budget.MaxProjects > 0 && after > c.Budget.MaxProjects {
+		return errors.Errorf(errors.ErrorResourceExhausted,
+			"organization %s has %d active projects, creating %d more exceeds TF_PREFLIGHT_MAX_PROJECTS=%d",
+			organization.ID, active, request.Projects, c.Budget.MaxProjects)
+	}
+	return nil
+}
+
+// ActiveProjects returns the number of projects of the organization counting against its plan limit
+func (c *Checker) ActiveProjects(ctx context.Context, organizationID string) (int, error) {
+	projects, err := c.Client.ListProjects(ctx)
+	if err != nil {
+		return 0, err
+	}
+	active := 0
+	for _, project := range projects {
+		if project.OrganizationID == organizationID && !inactiveStatuses[project.Status] {
+			active++
+		}
+	}
+	return active, nil
+}
+
+// apiError keeps a rate-limited Management API from reading as a budget the test goes over
+func apiError(err error) error {
+	if errors.IsKind(err, errors.ErrorResourceExhausted) {
+		return errors.Wrap(err, errors.ErrorUnknown, "preflight: Management API")
+	}
+	return err
+}
+
+// SkipT is a testing.TestingT able to skip the test, such as *testing.T
+type SkipT interface {
+	testing.TestingT
+	Skip(args ...interface{})
+}
+
+// Require skips the test, or fails it when the budget says so, when the fixture of options is over the budget
+// or the plan limits. Any other error, such as an unreachable API, fails the test.
+func (c *Checker) Require(t SkipT, options *terraform.Options) {
+	request, err := RequestFor(options)
+	require.NoError(t, err)
+	err = c.Check(context.Background(), request)
+	if err == nil {
+		return
+	}
+	if errors.IsKind(err, errors.ErrorResourceExhausted) && !c.Budget.Fail {
+		t.Skip(fmt.Sprintf("preflight: %v", err))
+		return
+	}
+	require.NoError(t, err, "preflight")
+}
+
+// Require checks options with the Checker configured by the environment
+func Require(t SkipT, options *terraform.Options) {
+	Default().Require(t, options)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package preflight
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/mockapi"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/recorder"
+)
+
+var testBudget = Budget{InstanceSizes: []string{"micro", "small"}}
+
+// server returns a mock API with a free and a pro organization, the free one has one active and one paused project
+func server(t *testing.T) *mockapi.Server {
+	t.Helper()
+	server := mockapi.New()
+	t.Cleanup(server.Close)
+	server.AddOrganization(management.Organization{ID: "hadenlabs", Plan: management.PlanFree})
+	server.AddOrganization(management.Organization{ID: "hadenlabs-pro", Plan: management.PlanPro})
+	server.AddProject(management.Project{ID: faker.Project().Ref(), OrganizationID: "hadenlabs", Status: "ACTIVE_HEALTHY"})
+	server.AddProject(management.Project{ID: faker.Project().Ref(), OrganizationID: "hadenlabs", Status: "INACTIVE"})
+	return server
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		budget  Budget
+		request Request
+		kind    errors.Kind
+		message string
+	}{
+		{
+			name:    "within budget",
+			budget:  testBudget,
+			request: Request{OrganizationID: "hadenlabs", InstanceSize: "micro", Projects: 1},
+		},
+		{
+			name:    "default instance size",
+			budget:  testBudget,
+			request: Request{OrganizationID: "hadenlabs", Projects: 1},
+		},
+		{
+			name:    "costly instance size",
+			budget:  testBudget,
+			request: Request{OrganizationID: "hadenlabs", InstanceSize: "xlarge", Projects: 1},
+			kind:    errors.ErrorResourceExhausted,
+			message: `instance size "xlarge" is over budget [micro, small], add it to TF_PREFLIGHT_INSTANCE_SIZES`,
+		},
+		{
+			name:    "free plan limit",
+			budget:  testBudget,
+			request: Request{OrganizationID: "hadenlabs", Projects: 2},
+			kind:    errors.ErrorResourceExhausted,
+			message: "organization hadenlabs on the free plan has 1 of 2 active projects, creating 2 more exceeds the plan limit",
+		},
+		{
+			name:    "paid plan",
+			budget:  testBudget,
+			request: Request{OrganizationID: "hadenlabs-pro", Projects: 5},
+		},
+		{
+			name:    "project budget",
+			budget:  Budget{MaxProjects: 3},
+			request: Request{OrganizationID: "hadenlabs-pro", Projects: 4},
+			kind:    errors.ErrorResourceExhausted,
+			message: "organization hadenlabs-pro has 0 active projects, creating 4 more exceeds TF_PREFLIGHT_MAX_PROJECTS=3",
+		},
+		{
+			name:    "unknown organization",
+			budget:  testBudget,
+			request: Request{OrganizationID: "acme", Projects: 1},
+			kind:    errors.ErrorNotFound,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := New(server(t).Client(), tc.budget)
+			err := checker.Check(context.Background(), tc.request)
+			if tc.kind == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.IsKind(err, tc.kind), err)
+			assert.Contains(t, err.Error(), tc.message)
+		})
+	}
+}
+
+func TestRequestFor(t *testing.T) {
+	t.Parallel()
+
+	request, err := RequestFor(&terraform.Options{
+		TerraformDir: t.TempDir(),
+		Vars:         map[string]interface{}{"organization_id": "hadenlabs", "instance_size": "large"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Request{OrganizationID: "hadenlabs", InstanceSize: "large", Projects: 1}, request)
+}
+
+func TestRequire(t *testing.T) {
+	t.Parallel()
+
+	options := &terraform.Options{
+		TerraformDir: t.TempDir(),
+		Vars:         map[string]interface{}{"organization_id": "hadenlabs", "instance_size": "xlarge"},
+	}
+	client := server(t).Client()
+
+	skipped := recorder.New(t)
+	New(client, testBudget).Require(skipped, options)
+	assert.True(t, skipped.Skipped, "an over budget test is skipped by default")
+	assert.False(t, skipped.Failed)
+
+	failed := recorder.New(t)
+	New(client, Budget{InstanceSizes: testBudget.InstanceSizes, Fail: true}).Require(failed, options)
+	assert.True(t, failed.Failed, "TF_PREFLIGHT_FAIL turns the skip into a failure")
+
+	allowed := recorder.New(t)
+	New(client, Budget{}).Require(allowed, options)
+	assert.False(t, allowed.Skipped || allowed.Failed)
+}
+
+func TestRequire_RateLimited(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	options := &terraform.Options{
+		TerraformDir: t.TempDir(),
+		Vars:         map[string]interface{}{"organization_id": "hadenlabs", "instance_size": "micro"},
+	}
+
+	r := recorder.New(t)
+	New(management.New(server.URL, "sbp_test"), testBudget).Require(r, options)
+	assert.False(t, r.Skipped, "a rate-limited API is not a budget")
+	assert.True(t, r.Failed)
+}
+
+func TestFromConfig(t *testing.T) {
+	t.Parallel()
+
+	conf := config.New()
+	conf.Preflight.InstanceSizes = []string{"micro"}
+	conf.Preflight.MaxProjects = 4
+	assert.Equal(t, Budget{InstanceSizes: []string{"micro"}, MaxProjects: 4}, FromConfig(conf))
+}
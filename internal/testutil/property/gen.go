@@ -0,0 +1,44 @@
+package property
+
+import (
+	"math/rand"
+)
+
+// Charsets for String
+const (
+	Lower        = "abcdefghijklmnopqrstuvwxyz"
+	Digits       = "0123456789"
+	Alphanumeric = Lower + "ABCDEFGHIJKLMNOPQRSTUVWXYZ" + Digits
+	Printable    = Alphanumeric + " !\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+)
+
+// String returns a string of length between minLen and minLen+size drawn from chars
+func String(r *rand.Rand, chars string, minLen, size int) string {
+	n := minLen + r.Intn(size+1)
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = chars[r.Intn(len(chars))]
+	}
+	return string(b)
+}
+
+// OneOf returns a random element of values
+func OneOf[T any](r *rand.Rand, values []T) T {
+	return values[r.Intn(len(values))]
+}
+
+// ShrinkString returns shorter variants of s, never shorter than minLen: the minimal
+// prefix, the first half, and s without each single character
+func ShrinkString(s string, minLen int) []string {
+	if len(s) <= minLen {
+		return nil
+	}
+	candidates := []string{s[:minLen]}
+	if half := len(s) / 2; half > minLen {
+		candidates = append(candidates, s[:half])
+	}
+	for i := range s {
+		candidates = append(candidates, s[:i]+s[i+1:])
+	}
+	return candidates
+}
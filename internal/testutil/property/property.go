@@ -0,0 +1,145 @@
+package property
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	coreconfig "github.com/hadenlabs/terraform-supabase/config"
+)
+
+// DefaultIterations is the number of values checked when Config.Iterations is zero
+const DefaultIterations = 100
+
+// DefaultMaxSize bounds the size hint passed to generators when Config.MaxSize is zero
+const DefaultMaxSize = 50
+
+// maxShrinks bounds the shrinking steps so a bad shrinker cannot loop forever
+const maxShrinks = 1000
+
+// Generator produces random values of T and smaller variants of a failing value
+type Generator[T any] struct {
+	// Generate returns a random value; size grows with the iteration
+	Generate func(r *rand.Rand, size int) T
+
+	// Shrink returns simpler candidates for v, simplest first; nil disables shrinking
+	Shrink func(v T) []T
+}
+
+// Config tunes a property check
+type Config struct {
+	// Iterations is the number of generated values to check
+	Iterations int
+
+	// MaxSize is the largest size hint passed to Generate
+	MaxSize int
+
+	// Seed makes a run reproducible; TF_PROPERTY_SEED or the current time is used when zero
+	Seed int64
+}
+
+// Failure describes a value falsifying a property
+type Failure[T any] struct {
+	// Seed reproduces the run
+	Seed int64
+
+	// Iteration is the iteration the property first failed at
+	Iteration int
+
+	// Original is the generated value that failed
+	Original T
+
+	// Shrunk is the simplest failing value found by shrinking
+	Shrunk T
+
+	// Shrinks is the number of successful shrinking steps
+	Shrinks int
+
+	// Err is the error returned by the property for Shrunk
+	Err error
+}
+
+// Error reports the shrunk counterexample and how to reproduce it
+func (f *Failure[T]) Error() string {
+	return fmt.Sprintf("property falsified after %d iterations (seed %d, %d shrinks): %v\ncounterexample: %+v\noriginal: %+v",
+		f.Iteration+1, f.Seed, f.Shrinks, f.Err, f.Shrunk, f.Original)
+}
+
+// Check runs prop against generated values and fails t with a shrunk counterexample
+func Check[T any](t testing.TestingT, gen Generator[T], prop func(T) error, config *Config) {
+	if failure := Run(gen, prop, config); failure != nil {
+		t.Errorf("%s", failure.Error())
+	}
+}
+
+// Run runs prop against generated values and returns the shrunk counterexample, or nil
+func Run[T any](gen Generator[T], prop func(T) error, config *Config) *Failure[T] {
+	if config == nil {
+		config = &Config{}
+	}
+	iterations := config.Iterations
+	if iterations == 0 {
+		iterations = DefaultIterations
+	}
+	maxSize := config.MaxSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxSize
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = coreconfig.Must().Property.Seed
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	r := rand.New(rand.NewSource(seed)) //nolint:gosec
+	for i := 0; i < iterations; i++ {
+		size := 1 + i*maxSize/iterations
+		v := gen.Generate(r, size)
+		err := prop(v)
+		if err == nil {
+			continue
+		}
+		failure := &Failure[T]{Seed: seed, Iteration: i, Original: v, Shrunk: v, Err: err}
+		shrink(gen, prop, failure)
+		return failure
+	}
+	return nil
+}
+
+// shrink greedily replaces the failing value with its first failing candidate until none fails
+func shrink[T any](gen Generator[T], prop func(T) error, failure *Failure[T]) {
+	if gen.Shrink == nil {
+		return
+	}
+	for failure.Shrinks < maxShrinks {
+		shrunk := false
+		for _, candidate := range gen.Shrink(failure.Shrunk) {
+			if err := prop(candidate); err != nil {
+				failure.Shrunk, failure.Err = candidate, err
+				failure.Shrinks++
+				shrunk = true
+				break
+			}
+		}
+		if !shrunk {
+			return
+		}
+	}
+}
+
+// Filter wraps a shrinker so it only proposes candidates accepted by keep
+func Filter[T any](shrinker func(T) []T, keep func(T) bool) func(T) []T {
+	return func(v T) []T {
+		candidates := []T{}
+		for _, candidate := range shrinker(v) {
+			if keep(candidate) {
+				candidates = append(candidates, candidate)
+			}
+		}
+		return candidates
+	}
+}
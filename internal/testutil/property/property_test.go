@@ -0,0 +1,76 @@
+package property
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var strings10 = Generator[string]{
+	Generate: func(r *rand.Rand, size int) string { return String(r, Lower, 0, size) },
+	Shrink:   func(s string) []string { return ShrinkString(s, 0) },
+}
+
+func TestRun_Passes(t *testing.T) {
+	t.Parallel()
+
+	failure := Run(strings10, func(s string) error {
+		if strings.ToLower(s) != s {
+			return fmt.Errorf("%q is not lowercase", s)
+		}
+		return nil
+	}, nil)
+	assert.Nil(t, failure)
+}
+
+func TestRun_Shrinks(t *testing.T) {
+	t.Parallel()
+
+	failure := Run(strings10, func(s string) error {
+		if strings.Contains(s, "e") {
+			return fmt.Errorf("%q contains e", s)
+		}
+		return nil
+	}, &Config{Iterations: 500, Seed: 42})
+	require.NotNil(t, failure)
+
+	assert.Equal(t, "e", failure.Shrunk, "counterexample should shrink to the minimal string")
+	assert.Equal(t, int64(42), failure.Seed)
+	assert.Contains(t, failure.Error(), "seed 42")
+}
+
+func TestRun_Reproducible(t *testing.T) {
+	t.Parallel()
+
+	prop := func(s string) error {
+		if len(s) > 20 {
+			return fmt.Errorf("too long")
+		}
+		return nil
+	}
+	first := Run(Generator[string]{Generate: strings10.Generate}, prop, &Config{Seed: 7})
+	second := Run(Generator[string]{Generate: strings10.Generate}, prop, &Config{Seed: 7})
+	require.NotNil(t, first)
+	assert.Equal(t, first.Original, second.Original)
+	assert.Equal(t, first.Iteration, second.Iteration)
+}
+
+func TestShrinkString(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, ShrinkString("ab", 2))
+	assert.Equal(t, []string{"a", "bc", "ac", "ab"}, ShrinkString("abc", 1))
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	shrink := Filter(func(s string) []string { return ShrinkString(s, 0) }, func(s string) bool {
+		return strings.HasPrefix(s, "a")
+	})
+	assert.Equal(t, []string{"a", "ac", "ab"}, shrink("abc"))
+}
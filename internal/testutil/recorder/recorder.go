@@ -0,0 +1,48 @@
+package recorder
+
+import (
+	"fmt"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Cleaner registers functions run when a test ends, such as *testing.T
+type Cleaner interface {
+	Cleanup(func())
+}
+
+// T is a TestingT recording failures, their messages and skips instead of failing the test, to check what
+// a helper reports. Unlike testing.T, FailNow, Fatal and Skip return.
+type T struct {
+	Failed  bool
+	Skipped bool
+	Message string
+
+	parent Cleaner
+}
+
+var _ testing.TestingT = (*T)(nil)
+
+// New returns a T that has not failed, whose cleanups run when parent ends
+func New(parent Cleaner) *T {
+	return &T{parent: parent}
+}
+
+func (r *T) Fail()                                     { r.Failed = true }
+func (r *T) FailNow()                                  { r.Failed = true }
+func (r *T) Fatal(args ...interface{})                 { r.record(fmt.Sprint(args...)) }
+func (r *T) Fatalf(format string, args ...interface{}) { r.record(fmt.Sprintf(format, args...)) }
+func (r *T) Error(args ...interface{})                 { r.record(fmt.Sprint(args...)) }
+func (r *T) Errorf(format string, args ...interface{}) { r.record(fmt.Sprintf(format, args...)) }
+func (r *T) Name() string                              { return "recorder" }
+
+// Skip records the skip, the test goes on
+func (r *T) Skip(args ...interface{}) { r.Skipped = true }
+
+// Cleanup runs f when the parent test ends, so files a recorded helper writes are still removed
+func (r *T) Cleanup(f func()) { r.parent.Cleanup(f) }
+
+func (r *T) record(message string) {
+	r.Failed = true
+	r.Message += message
+}
@@ -0,0 +1,42 @@
+package recorder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/preflight"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/supabase"
+)
+
+var (
+	_ supabase.CleanupT = (*T)(nil)
+	_ preflight.SkipT   = (*T)(nil)
+)
+
+func TestT(t *testing.T) {
+	t.Parallel()
+
+	r := New(t)
+	assert.True(t, assert.Equal(r, 1, 1))
+	assert.False(t, r.Failed)
+
+	require.Equal(r, "want", "got")
+	assert.True(t, r.Failed, "FailNow returns")
+	assert.Contains(t, r.Message, `expected: "want"`)
+
+	r.Skip("over budget")
+	assert.True(t, r.Skipped)
+}
+
+func TestT_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	ran := false
+	t.Run("helper", func(t *testing.T) {
+		New(t).Cleanup(func() { ran = true })
+		assert.False(t, ran)
+	})
+	assert.True(t, ran, "cleanups run when the parent test ends")
+}
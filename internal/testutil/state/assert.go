@@ -0,0 +1,91 @@
+package state
+
+import (
+	"encoding/json"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// ResourceE runs `terraform show -json` and returns the instance at address, see State.Resource
+func ResourceE(t testing.TestingT, options *terraform.Options, address string) (Resource, error) {
+	s, err := ShowE(t, options)
+	if err != nil {
+		return Resource{}, err
+	}
+	return s.Resource(address)
+}
+
+// GetResource is like ResourceE but fails the test on error
+func GetResource(t testing.TestingT, options *terraform.Options, address string) Resource {
+	r, err := ResourceE(t, options, address)
+	require.NoError(t, err)
+	return r
+}
+
+// DecodeResource runs `terraform show -json` and decodes the attributes of the instance at address into T.
+// Fields of T are matched to attributes through their json tags.
+func DecodeResource[T any](t testing.TestingT, options *terraform.Options, address string) *T {
+	out, err := DecodeResourceE[T](t, options, address)
+	require.NoError(t, err)
+	return out
+}
+
+// DecodeResourceE runs `terraform show -json` and decodes the attributes of the instance at address into T
+func DecodeResourceE[T any](t testing.TestingT, options *terraform.Options, address string) (*T, error) {
+	r, err := ResourceE(t, options, address)
+	if err != nil {
+		return nil, err
+	}
+	return Decode[T](r)
+}
+
+// Decode decodes the attributes of r into T
+func Decode[T any](r Resource) (*T, error) {
+	data, err := json.Marshal(r.Values)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.ErrorUnknown, "encode attributes of %s", r.Address)
+	}
+	result := new(T)
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, errors.Wrapf(err, errors.ErrorInvalidArgument, "decode attributes of %s into %T", r.Address, result)
+	}
+	return result, nil
+}
+
+// AssertResourceAttr asserts the attribute at path of the instance at address equals want, numbers compare
+// by value so an int want matches the float64 decoded from JSON. Sensitive values stay out of the failure message.
+func AssertResourceAttr(t testing.TestingT, options *terraform.Options, address, path string, want interface{}) bool {
+	r, err := ResourceE(t, options, address)
+	if !assert.NoError(t, err) {
+		return false
+	}
+	got, err := r.Attr(path)
+	if !assert.NoError(t, err) {
+		return false
+	}
+	if r.Sensitive(path) {
+		return assert.True(t, assert.ObjectsAreEqualValues(want, got), "%s.%s (sensitive value) differs", r.Address, path)
+	}
+	return assert.EqualValues(t, want, got, "%s.%s", r.Address, path)
+}
+
+// AssertNoResource asserts the state holds no instance at address, such as for a fixture with module_enabled false
+func AssertNoResource(t testing.TestingT, options *terraform.Options, address string) bool {
+	s, err := ShowE(t, options)
+	if !assert.NoError(t, err) {
+		return false
+	}
+	r, err := s.Resource(address)
+	if errors.IsKind(err, errors.ErrorNotFound) {
+		return true
+	}
+	if !assert.NoError(t, err) {
+		return false
+	}
+	return assert.Fail(t, "unexpected resource", "%s is in the state", r.Address)
+}
@@ -0,0 +1,96 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/recorder"
+)
+
+// fakeTerraform prints STATE_JSON on show
+const fakeTerraform = `#!/bin/sh
+case "$1" in
+  show) cat "$STATE_JSON" ;;
+esac
+`
+
+func fakeOptions(t *testing.T) *terraform.Options {
+	t.Helper()
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "terraform")
+	require.NoError(t, os.WriteFile(binary, []byte(fakeTerraform), 0o700)) //nolint:gosec
+	state, err := filepath.Abs(filepath.Join("testdata", "state.json"))
+	require.NoError(t, err)
+
+	return &terraform.Options{
+		TerraformDir:    dir,
+		TerraformBinary: binary,
+		NoColor:         true,
+		EnvVars:         map[string]string{"STATE_JSON": state},
+	}
+}
+
+func TestAssertResourceAttr(t *testing.T) {
+	t.Parallel()
+
+	options := fakeOptions(t)
+	assert.True(t, AssertResourceAttr(t, options, "supabase_project.this[0]", "region", "us-east-1"))
+	assert.True(t, AssertResourceAttr(t, options, "supabase_project.this[0]", "legacy_api_keys_enabled", false))
+	assert.True(t, AssertResourceAttr(t, options, `supabase_settings.this["api"]`, "api.max_rows", 1000))
+
+	cases := []struct {
+		name    string
+		address string
+		path    string
+		want    interface{}
+	}{
+		{name: "different value", address: "supabase_project.this[0]", path: "region", want: "eu-west-1"},
+		{name: "sensitive value", address: "supabase_project.this[0]", path: "database_password", want: "other"},
+		{name: "missing attribute", address: "supabase_project.this[0]", path: "plan", want: "free"},
+		{name: "missing resource", address: "supabase_project.this[1]", path: "region", want: "us-east-1"},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := recorder.New(t)
+			assert.False(t, AssertResourceAttr(r, options, tc.address, tc.path, tc.want))
+			assert.True(t, r.Failed)
+			assert.NotContains(t, r.Message, "s3cr3t-Passw0rd", "sensitive values stay out of the failure message")
+		})
+	}
+}
+
+func TestAssertNoResource(t *testing.T) {
+	t.Parallel()
+
+	options := fakeOptions(t)
+	assert.True(t, AssertNoResource(t, options, "supabase_project.this[1]"))
+
+	r := recorder.New(t)
+	assert.False(t, AssertNoResource(r, options, "supabase_project.this[0]"))
+	assert.True(t, r.Failed)
+}
+
+func TestDecodeResource(t *testing.T) {
+	t.Parallel()
+
+	type project struct {
+		ID                   string `json:"id"`
+		InstanceSize         string `json:"instance_size"`
+		LegacyAPIKeysEnabled bool   `json:"legacy_api_keys_enabled"`
+	}
+	got := DecodeResource[project](t, fakeOptions(t), "module.supabase_project.supabase_project.this[0]")
+	assert.Equal(t, &project{ID: "abcdefghijklmnopqrst", InstanceSize: "micro"}, got)
+
+	_, err := DecodeResourceE[struct {
+		InstanceSize int `json:"instance_size"`
+	}](t, fakeOptions(t), "supabase_project.this[0]")
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package state
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// Attr returns the attribute at path, nested attributes use paths such as settings.name, tags[0]
+// or labels["env"]
+func (r Resource) Attr(path string) (interface{}, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := walk(r.Values, steps)
+	if !ok {
+		return nil, errors.Errorf(errors.ErrorNotFound, "%s has no attribute %s", r.Address, path)
+	}
+	return value, nil
+}
+
+// String returns the string attribute at path
+func (r Resource) String(path string) (string, error) {
+	value, err := r.Attr(path)
+	if err != nil {
+		return "", err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", r.typeError(path, "a string", value)
+	}
+	return s, nil
+}
+
+// Bool returns the bool attribute at path
+func (r Resource) Bool(path string) (bool, error) {
+	value, err := r.Attr(path)
+	if err != nil {
+		return false, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, r.typeError(path, "a bool", value)
+	}
+	return b, nil
+}
+
+// Int returns the whole number attribute at path
+func (r Resource) Int(path string) (int, error) {
+	value, err := r.Attr(path)
+	if err != nil {
+		return 0, err
+	}
+	f, ok := value.(float64)
+	if !ok || f != math.Trunc(f) {
+		return 0, r.typeError(path, "a whole number", value)
+	}
+	return int(f), nil
+}
+
+// Sensitive reports whether the attribute at path, or the attribute holding it, is sensitive
+func (r Resource) Sensitive(path string) bool {
+	steps, err := parsePath(path)
+	if err != nil {
+		return false
+	}
+	value := r.sensitive
+	for _, step := range steps {
+		if b, ok := value.(bool); ok {
+			return b
+		}
+		next, ok := walk(value, []interface{}{step})
+		if !ok {
+			return false
+		}
+		value = next
+	}
+	b, _ := value.(bool)
+	return b
+}
+
+func (r Resource) typeError(path, want string, value interface{}) error {
+	if r.Sensitive(path) {
+		return errors.Errorf(errors.ErrorInvalidArgument, "%s.%s is not %s", r.Address, path, want)
+	}
+	return errors.Errorf(errors.ErrorInvalidArgument, "%s.%s is not %s: %v", r.Address, path, want, value)
+}
+
+// walk follows steps, attribute names and list indexes, through value
+func walk(value interface{}, steps []interface{}) (interface{}, bool) {
+	for _, step := range steps {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			name, ok := step.(string)
+			if !ok {
+				return nil, false
+			}
+			if value, ok = v[name]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, ok := step.(int)
+			if !ok || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// parsePath splits a path such as settings.tags[0] or labels["env"] into attribute names and list indexes
+func parsePath(path string) ([]interface{}, error) {
+	steps := []interface{}{}
+	rest := path
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, `["`):
+			end := strings.Index(rest, `"]`)
+			if end < 0 {
+				return nil, invalidPath(path)
+			}
+			steps = append(steps, rest[2:end])
+			rest = rest[end+2:]
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, invalidPath(path)
+			}
+			i, err := strconv.Atoi(rest[1:end])
+			if err != nil {
+				return nil, invalidPath(path)
+			}
+			steps = append(steps, i)
+			rest = rest[end+1:]
+		default:
+			rest = strings.TrimPrefix(rest, ".")
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, invalidPath(path)
+			}
+			steps = append(steps, rest[:end])
+			rest = rest[end:]
+		}
+	}
+	if len(steps) == 0 {
+		return nil, invalidPath(path)
+	}
+	return steps, nil
+}
+
+func invalidPath(path string) error {
+	return errors.Errorf(errors.ErrorInvalidArgument, "invalid attribute path %q", path)
+}
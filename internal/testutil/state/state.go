@@ -0,0 +1,193 @@
+package state
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// Resource modes
+const (
+	ModeManaged = "managed"
+	ModeData    = "data"
+)
+
+// State is the state of a root module as rendered by `terraform show -json`
+type State struct {
+	TerraformVersion string
+
+	// Resources are the resource instances of every module, nested modules flattened, in state order
+	Resources []Resource
+}
+
+// Resource is a resource instance of the state
+type Resource struct {
+	// Address is the absolute address, such as module.supabase_project.supabase_project.this[0]
+	Address string
+
+	// Module is the address of the module holding the resource, empty in the root module
+	Module string
+
+	Mode string
+	Type string
+	Name string
+
+	// Index is the count index, an int, or the for_each key, a string, nil for single instances
+	Index interface{}
+
+	// Values are the attributes, sensitive ones included in clear text
+	Values map[string]interface{}
+
+	// sensitive mirrors Values with true for every sensitive attribute
+	sensitive interface{}
+}
+
+// stateJSON is the part of `terraform show -json` the state is read from
+type stateJSON struct {
+	TerraformVersion string `json:"terraform_version"`
+	Values           struct {
+		RootModule moduleJSON `json:"root_module"`
+	} `json:"values"`
+}
+
+type moduleJSON struct {
+	Address   string `json:"address"`
+	Resources []struct {
+		Address         string                 `json:"address"`
+		Mode            string                 `json:"mode"`
+		Type            string                 `json:"type"`
+		Name            string                 `json:"name"`
+		Index           interface{}            `json:"index"`
+		Values          map[string]interface{} `json:"values"`
+		SensitiveValues interface{}            `json:"sensitive_values"`
+	} `json:"resources"`
+	ChildModules []moduleJSON `json:"child_modules"`
+}
+
+// Parse reads a state rendered by `terraform show -json`, an empty state has no resources
+func Parse(data []byte) (*State, error) {
+	in := stateJSON{}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, errors.Wrap(err, errors.ErrorInvalidArgument, "parse state json")
+	}
+	return &State{
+		TerraformVersion: in.TerraformVersion,
+		Resources:        in.Values.RootModule.resources(),
+	}, nil
+}
+
+func (m moduleJSON) resources() []Resource {
+	resources := []Resource{}
+	for _, r := range m.Resources {
+		resources = append(resources, Resource{
+			Address:   r.Address,
+			Module:    m.Address,
+			Mode:      r.Mode,
+			Type:      r.Type,
+			Name:      r.Name,
+			Index:     index(r.Index),
+			Values:    r.Values,
+			sensitive: r.SensitiveValues,
+		})
+	}
+	for _, child := range m.ChildModules {
+		resources = append(resources, child.resources()...)
+	}
+	return resources
+}
+
+// index turns a count index, decoded as float64, back into an int
+func index(v interface{}) interface{} {
+	if f, ok := v.(float64); ok && f == math.Trunc(f) {
+		return int(f)
+	}
+	return v
+}
+
+// ShowE runs `terraform show -json` on the state of options
+func ShowE(t testing.TestingT, options *terraform.Options) (*State, error) {
+	showOptions, err := options.Clone()
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorUnknown, "clone terraform options")
+	}
+	// Without a plan file terraform show renders the state
+	showOptions.PlanFilePath = ""
+	out, err := terraform.ShowE(t, showOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorUnknown, "terraform show")
+	}
+	return Parse([]byte(out))
+}
+
+// Show is like ShowE but fails the test on error
+func Show(t testing.TestingT, options *terraform.Options) *State {
+	s, err := ShowE(t, options)
+	require.NoError(t, err)
+	return s
+}
+
+// Managed returns the managed resources, data sources excluded
+func (s *State) Managed() []Resource {
+	managed := []Resource{}
+	for _, r := range s.Resources {
+		if r.Mode == ModeManaged {
+			managed = append(managed, r)
+		}
+	}
+	return managed
+}
+
+// Resource returns the instance at address. The address is either absolute or relative to one of the modules
+// holding the resource, so supabase_project.this[0] finds module.supabase_project.supabase_project.this[0]
+// as long as no other module has one. Instance keys are part of the address: supabase_project.this does not
+// match supabase_project.this[0].
+func (s *State) Resource(address string) (Resource, error) {
+	matches := []Resource{}
+	for _, r := range s.Resources {
+		if r.Address == address {
+			return r, nil
+		}
+		if r.matches(address) {
+			matches = append(matches, r)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Resource{}, errors.Errorf(errors.ErrorNotFound, "no resource %s in the state, it holds %s", address, s.addresses())
+	case 1:
+		return matches[0], nil
+	}
+	ambiguous := make([]string, 0, len(matches))
+	for _, r := range matches {
+		ambiguous = append(ambiguous, r.Address)
+	}
+	return Resource{}, errors.Errorf(errors.ErrorInvalidArgument, "%s is ambiguous, it matches %s", address, strings.Join(ambiguous, ", "))
+}
+
+// matches reports whether address is the address of r relative to the module holding it or to one of its parents
+func (r Resource) matches(address string) bool {
+	if r.Module == "" || !strings.HasSuffix(r.Address, "."+address) {
+		return false
+	}
+	prefix := strings.TrimSuffix(r.Address, "."+address)
+	return prefix == r.Module || strings.HasPrefix(r.Module, prefix+".module.")
+}
+
+func (s *State) addresses() string {
+	if len(s.Resources) == 0 {
+		return "nothing"
+	}
+	addresses := make([]string, 0, len(s.Resources))
+	for _, r := range s.Resources {
+		addresses = append(addresses, r.Address)
+	}
+	sort.Strings(addresses)
+	return strings.Join(addresses, ", ")
+}
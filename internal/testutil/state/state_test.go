@@ -0,0 +1,145 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+func readState(t *testing.T) *State {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "state.json"))
+	require.NoError(t, err)
+	s, err := Parse(data)
+	require.NoError(t, err)
+	return s
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	s := readState(t)
+	assert.Equal(t, "1.9.8", s.TerraformVersion)
+	require.Len(t, s.Resources, 5)
+	assert.Len(t, s.Managed(), 4, "data sources are not managed")
+
+	project := s.Resources[2]
+	assert.Equal(t, "module.supabase_project", project.Module)
+	assert.Equal(t, ModeManaged, project.Mode)
+	assert.Equal(t, "supabase_project", project.Type)
+	assert.Equal(t, "this", project.Name)
+	assert.Equal(t, 0, project.Index, "count indexes are ints")
+	assert.Equal(t, "api", s.Resources[4].Index, "for_each keys are strings")
+	assert.Nil(t, s.Resources[0].Index)
+
+	empty, err := Parse([]byte(`{"format_version":"1.0"}`))
+	require.NoError(t, err)
+	assert.Empty(t, empty.Resources)
+
+	_, err = Parse([]byte("{"))
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+}
+
+func TestState_Resource(t *testing.T) {
+	t.Parallel()
+
+	s := readState(t)
+	cases := []struct {
+		address string
+		want    string
+		kind    errors.Kind
+	}{
+		{address: "module.supabase_project.supabase_project.this[0]", want: "module.supabase_project.supabase_project.this[0]"},
+		{address: "supabase_project.this[0]", want: "module.supabase_project.supabase_project.this[0]"},
+		{address: "data.supabase_pooler.this[0]", want: "module.supabase_project.data.supabase_pooler.this[0]"},
+		{address: "terraform_data.marker", want: "terraform_data.marker"},
+		{address: `supabase_settings.this["api"]`, want: `module.supabase_apikey.module.settings.supabase_settings.this["api"]`},
+		{address: `module.settings.supabase_settings.this["api"]`, want: `module.supabase_apikey.module.settings.supabase_settings.this["api"]`},
+		{address: "supabase_project.this", kind: errors.ErrorNotFound},
+		{address: "this[0]", kind: errors.ErrorNotFound},
+		{address: "supabase_project.this[1]", kind: errors.ErrorNotFound},
+		{address: "ings.supabase_settings.this[\"api\"]", kind: errors.ErrorNotFound},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.address, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := s.Resource(tc.address)
+			if tc.kind != "" {
+				assert.True(t, errors.IsKind(err, tc.kind), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, r.Address)
+		})
+	}
+}
+
+func TestState_Resource_Ambiguous(t *testing.T) {
+	t.Parallel()
+
+	s := &State{Resources: []Resource{
+		{Address: "module.a.supabase_project.this[0]", Module: "module.a"},
+		{Address: "module.b.supabase_project.this[0]", Module: "module.b"},
+	}}
+	_, err := s.Resource("supabase_project.this[0]")
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+	assert.Contains(t, err.Error(), "module.a.supabase_project.this[0], module.b.supabase_project.this[0]")
+
+	r, err := s.Resource("module.b.supabase_project.this[0]")
+	require.NoError(t, err)
+	assert.Equal(t, "module.b", r.Module)
+}
+
+func TestResource_Attr(t *testing.T) {
+	t.Parallel()
+
+	settings, err := readState(t).Resource(`supabase_settings.this["api"]`)
+	require.NoError(t, err)
+
+	value, err := settings.Attr("api.db_extra_search_path[1]")
+	require.NoError(t, err)
+	assert.Equal(t, "extensions", value)
+
+	s, err := settings.String(`auth["site_url"]`)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", s)
+
+	n, err := settings.Int("api.max_rows")
+	require.NoError(t, err)
+	assert.Equal(t, 1000, n)
+
+	_, err = settings.Bool("api.max_rows")
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+	assert.Contains(t, err.Error(), "is not a bool: 1000")
+
+	_, err = settings.Int("auth.jwt_secret")
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+	assert.NotContains(t, err.Error(), "super-secret-jwt", "sensitive values stay out of errors")
+
+	for _, path := range []string{"api.missing", "api.db_extra_search_path[2]", "api.db_schema.name"} {
+		_, err = settings.Attr(path)
+		assert.True(t, errors.IsKind(err, errors.ErrorNotFound), path)
+	}
+	for _, path := range []string{"", "api..db_schema", "api[x]", `auth["site_url`} {
+		_, err = settings.Attr(path)
+		assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument), path)
+	}
+}
+
+func TestResource_Sensitive(t *testing.T) {
+	t.Parallel()
+
+	settings, err := readState(t).Resource(`supabase_settings.this["api"]`)
+	require.NoError(t, err)
+	assert.True(t, settings.Sensitive("auth.jwt_secret"))
+	assert.False(t, settings.Sensitive("auth.site_url"))
+	assert.False(t, settings.Sensitive("api.db_extra_search_path[0]"))
+	assert.False(t, settings.Sensitive("id"))
+}
@@ -103,8 +103,8 @@ func Demo() {
 	fmt.Println(`   // In test file:
    terraformOptions := DefaultForModuleWithFaker("modules/project")
    terraformOptions.Vars["module_enabled"] = true
-   // defer terraform.Destroy(t, terraformOptions)
-   // terraform.InitAndApply(t, terraformOptions)`)
+   // defer ledger.Destroy(t, terraformOptions)
+   // ledger.InitAndApply(t, terraformOptions)`)
 
 	// Section 6: Validation and Helpers
 	fmt.Println("\n\n6. VALIDATION & HELPERS")
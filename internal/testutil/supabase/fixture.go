@@ -0,0 +1,49 @@
+package supabase
+
+import (
+	"github.com/hadenlabs/terraform-supabase/internal/fixture"
+)
+
+// Fixtures live in internal/fixture so the terraform-supabase command can build them without linking terratest
+
+// Project is fixture.Project
+type Project = fixture.Project
+
+// APIKey is fixture.APIKey
+type APIKey = fixture.APIKey
+
+// ProjectWithAPIKeys is fixture.ProjectWithAPIKeys
+type ProjectWithAPIKeys = fixture.ProjectWithAPIKeys
+
+// ProjectModule is the module under modules/ whose variables a Project carries
+const ProjectModule = fixture.ProjectModule
+
+// NewProject creates a new Project instance with default values, see fixture.NewProject
+func NewProject() *Project {
+	return fixture.NewProject()
+}
+
+// NewProjectWithFaker creates a new Project instance with all fields from faker
+func NewProjectWithFaker() *Project {
+	return fixture.NewProjectWithFaker()
+}
+
+// NewAPIKey creates a new APIKey instance with values from faker
+func NewAPIKey() *APIKey {
+	return fixture.NewAPIKey()
+}
+
+// NewProjectWithAPIKeys creates a stack of a default project and n API keys
+func NewProjectWithAPIKeys(n int) *ProjectWithAPIKeys {
+	return fixture.NewProjectWithAPIKeys(n)
+}
+
+// ProjectFromTFVars reads a terraform.tfvars or .tfvars.json file into a Project
+func ProjectFromTFVars(path string) (*Project, error) {
+	return fixture.ProjectFromTFVars(path)
+}
+
+// ProjectFromMap builds a Project from Terraform variables, see fixture.ProjectFromMap
+func ProjectFromMap(vars map[string]interface{}) (*Project, error) {
+	return fixture.ProjectFromMap(vars)
+}
@@ -0,0 +1,47 @@
+package supabase
+
+import (
+	"testing"
+
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/golden"
+)
+
+// Snapshots of the variables sent to Terraform; run `UPDATE_GOLDEN=true go test ./internal/testutil/supabase` after intended changes.
+
+func TestGolden_ProjectToMap(t *testing.T) {
+	t.Parallel()
+
+	project := NewProject().WithName("tftest-golden").WithRegion("us-east-1")
+	golden.AssertVars(t, "project_vars", project.ToMap(), golden.MaskKeys("database_password"))
+}
+
+func TestGolden_MergeProjectValues(t *testing.T) {
+	t.Parallel()
+
+	vars := MergeProjectValues(map[string]interface{}{
+		"name":           "tftest-golden",
+		"region":         "eu-west-1",
+		"instance_size":  "small",
+		"module_enabled": false,
+	})
+	golden.AssertVars(t, "merge_project_values", vars, golden.MaskKeys("database_password"))
+}
+
+func TestGolden_ProjectWithAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	stack := NewProjectWithAPIKeys(0).
+		WithProject(NewProject().WithName("tftest-golden").WithRegion("us-east-1")).
+		WithAPIKeys(
+			NewAPIKey().WithName("ci_key").WithDescription("CI pipeline"),
+			NewAPIKey().WithName("backend_key").WithDescription("Backend service"),
+		)
+	golden.AssertVars(t, "project_with_apikeys_vars", stack.ToMap(), golden.MaskKeys("database_password"))
+}
+
+func TestGolden_APIKeyToMap(t *testing.T) {
+	t.Parallel()
+
+	key := NewAPIKey().WithProjectID("abcdefghijklmnopqrst").WithName("ci_key").WithDescription("CI pipeline")
+	golden.AssertVars(t, "apikey_vars", key.ToMap())
+}
@@ -0,0 +1,26 @@
+package supabase
+
+import (
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+)
+
+// AssertAPIKey checks that the api_key output is a well-formed value of the type output
+// and that secret keys carry a JWT template
+func AssertAPIKey(t testing.TestingT, outputs *APIKeyOutputs) bool {
+	if !assert.NoError(t, faker.VerifyAPIKey(outputs.APIKey.Reveal(), outputs.Type), "api_key should match type %q", outputs.Type) {
+		return false
+	}
+	if outputs.Type == faker.APIKeyTypeSecret {
+		return assert.NotNil(t, outputs.SecretJWTTemplate, "secret keys should have a secret_jwt_template")
+	}
+	return true
+}
+
+// AssertLegacyJWT checks that token is a legacy key signed with secret for the given role and project
+func AssertLegacyJWT(t testing.TestingT, token Secret, secret, role, ref string) bool {
+	_, err := faker.VerifyLegacyJWT(token.Reveal(), secret, role, ref)
+	return assert.NoError(t, err, "legacy %s key of project %s", role, ref)
+}
@@ -0,0 +1,43 @@
+package supabase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/recorder"
+)
+
+func TestAssertAPIKey(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, AssertAPIKey(t, &APIKeyOutputs{
+		APIKey:            Secret(faker.APIKeyValue(faker.APIKeyTypeSecret)),
+		Type:              faker.APIKeyTypeSecret,
+		SecretJWTTemplate: &SecretJWTTemplate{Role: faker.RoleServiceRole},
+	}))
+	assert.True(t, AssertAPIKey(t, &APIKeyOutputs{
+		APIKey: Secret(faker.APIKeyValue(faker.APIKeyTypePublishable)),
+		Type:   faker.APIKeyTypePublishable,
+	}))
+
+	mock := recorder.New(t)
+	assert.False(t, AssertAPIKey(mock, &APIKeyOutputs{
+		APIKey: Secret(faker.APIKeyValue(faker.APIKeyTypePublishable)),
+		Type:   faker.APIKeyTypeSecret,
+	}))
+	assert.True(t, mock.Failed)
+}
+
+func TestAssertLegacyJWT(t *testing.T) {
+	t.Parallel()
+
+	ref := faker.Project().Ref()
+	token := Secret(faker.LegacyJWT(faker.RoleAnon, ref))
+
+	assert.True(t, AssertLegacyJWT(t, token, faker.TestJWTSecret, faker.RoleAnon, ref))
+	mock := recorder.New(t)
+	assert.False(t, AssertLegacyJWT(mock, token, faker.TestJWTSecret, faker.RoleServiceRole, ref))
+	assert.True(t, mock.Failed)
+}
@@ -0,0 +1,157 @@
+package supabase
+
+import (
+	"encoding/json"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// redacted is the placeholder printed instead of a Secret value
+const redacted = "[REDACTED]"
+
+// Secret holds a sensitive output value and redacts it when printed or marshaled
+type Secret string
+
+// String returns a redacted placeholder so secrets never end up in test logs
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// GoString returns a redacted placeholder for %#v formatting
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+// MarshalJSON marshals the redacted placeholder instead of the secret value
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON reads the secret value from a JSON string
+func (s *Secret) UnmarshalJSON(data []byte) error {
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == nil {
+		*s = ""
+		return nil
+	}
+	*s = Secret(*value)
+	return nil
+}
+
+// Reveal returns the underlying secret value
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// ProjectOutputs mirrors the outputs declared in modules/project/outputs.tf
+type ProjectOutputs struct {
+	// ID is the identifier of the created project
+	ID string `json:"id"`
+
+	// ModuleEnabled reports whether the module created its resources
+	ModuleEnabled bool `json:"module_enabled"`
+}
+
+// SecretJWTTemplate mirrors the secret_jwt_template attribute of supabase_apikey
+type SecretJWTTemplate struct {
+	// Role is the Postgres role claimed by JWTs minted from the key
+	Role string `json:"role"`
+}
+
+// APIKeyResource mirrors the full supabase_apikey object exposed by the apikey output
+type APIKeyResource struct {
+	ID                string             `json:"id"`
+	ProjectRef        string             `json:"project_ref"`
+	Name              string             `json:"name"`
+	Description       *string            `json:"description"`
+	APIKey            Secret             `json:"api_key"`
+	Type              string             `json:"type"`
+	SecretJWTTemplate *SecretJWTTemplate `json:"secret_jwt_template"`
+}
+
+// APIKeyOutputs mirrors the outputs declared in modules/apikey/outputs.tf
+type APIKeyOutputs struct {
+	// ID is the identifier of the created API key
+	ID string `json:"id"`
+
+	// APIKey is the sensitive key value
+	APIKey Secret `json:"api_key"`
+
+	// Type is the type of the API key
+	Type string `json:"type"`
+
+	// SecretJWTTemplate is the JWT template of secret keys
+	SecretJWTTemplate *SecretJWTTemplate `json:"secret_jwt_template"`
+
+	// APIKeyResource holds all attributes of the created resource
+	APIKeyResource *APIKeyResource `json:"apikey"`
+
+	// ProjectRef echoes the project reference input
+	ProjectRef string `json:"project_ref"`
+
+	// Name echoes the name input
+	Name string `json:"name"`
+
+	// Description echoes the description input
+	Description *string `json:"description"`
+
+	// ModuleEnabled reports whether the module created its resources
+	ModuleEnabled bool `json:"module_enabled"`
+}
+
+// outputValue is a single entry of `terraform output -json`
+type outputValue struct {
+	Sensitive bool            `json:"sensitive"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// DecodeOutputs runs `terraform output -json` and decodes every output into T.
+// Fields of T are matched to outputs through their json tags.
+func DecodeOutputs[T any](t testing.TestingT, options *terraform.Options) *T {
+	out, err := DecodeOutputsE[T](t, options)
+	require.NoError(t, err)
+	return out
+}
+
+// DecodeOutputsE runs `terraform output -json` and decodes every output into T
+func DecodeOutputsE[T any](t testing.TestingT, options *terraform.Options) (*T, error) {
+	raw, err := terraform.OutputJsonE(t, options, "")
+	if err != nil {
+		return nil, err
+	}
+	return DecodeOutputsJSON[T]([]byte(raw))
+}
+
+// DecodeOutputsJSON decodes the document printed by `terraform output -json` into T
+func DecodeOutputsJSON[T any](data []byte) (*T, error) {
+	outputs := map[string]outputValue{}
+	if err := json.Unmarshal(data, &outputs); err != nil {
+		return nil, errors.Wrap(err, errors.ErrorInvalidArgument, "decode terraform outputs")
+	}
+
+	values := make(map[string]json.RawMessage, len(outputs))
+	for name, output := range outputs {
+		values[name] = output.Value
+	}
+
+	flattened, err := json.Marshal(values)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorUnknown, "flatten terraform outputs")
+	}
+
+	result := new(T)
+	if err := json.Unmarshal(flattened, result); err != nil {
+		return nil, errors.Wrapf(err, errors.ErrorInvalidArgument, "decode terraform outputs into %T", result)
+	}
+	return result, nil
+}
@@ -0,0 +1,98 @@
+package supabase
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const apikeyOutputsJSON = `{
+  "api_key": {"sensitive": true, "type": "string", "value": "sb_secret_abc123"},
+  "apikey": {
+    "sensitive": false,
+    "type": ["object", {}],
+    "value": {
+      "id": "key-id",
+      "project_ref": "mayuaycdtijbctgqbycg",
+      "name": "backend_key",
+      "description": null,
+      "api_key": "sb_secret_abc123",
+      "type": "secret",
+      "secret_jwt_template": {"role": "service_role"}
+    }
+  },
+  "description": {"sensitive": false, "type": "string", "value": null},
+  "id": {"sensitive": false, "type": "string", "value": "key-id"},
+  "module_enabled": {"sensitive": false, "type": "bool", "value": true},
+  "name": {"sensitive": false, "type": "string", "value": "backend_key"},
+  "project_ref": {"sensitive": false, "type": "string", "value": "mayuaycdtijbctgqbycg"},
+  "secret_jwt_template": {"sensitive": false, "type": ["object", {}], "value": {"role": "service_role"}},
+  "type": {"sensitive": false, "type": "string", "value": "secret"}
+}`
+
+func TestDecodeOutputsJSON_APIKey(t *testing.T) {
+	t.Parallel()
+
+	outputs, err := DecodeOutputsJSON[APIKeyOutputs]([]byte(apikeyOutputsJSON))
+	require.NoError(t, err)
+
+	assert.Equal(t, "key-id", outputs.ID)
+	assert.Equal(t, "sb_secret_abc123", outputs.APIKey.Reveal())
+	assert.Equal(t, "secret", outputs.Type)
+	assert.Equal(t, "service_role", outputs.SecretJWTTemplate.Role)
+	assert.Equal(t, "mayuaycdtijbctgqbycg", outputs.ProjectRef)
+	assert.Equal(t, "backend_key", outputs.Name)
+	assert.Nil(t, outputs.Description)
+	assert.True(t, outputs.ModuleEnabled)
+	require.NotNil(t, outputs.APIKeyResource)
+	assert.Equal(t, "sb_secret_abc123", outputs.APIKeyResource.APIKey.Reveal())
+}
+
+func TestDecodeOutputsJSON_ProjectDisabled(t *testing.T) {
+	t.Parallel()
+
+	data := `{
+  "id": {"sensitive": false, "type": "string", "value": null},
+  "module_enabled": {"sensitive": false, "type": "bool", "value": false}
+}`
+
+	outputs, err := DecodeOutputsJSON[ProjectOutputs]([]byte(data))
+	require.NoError(t, err)
+	assert.Empty(t, outputs.ID)
+	assert.False(t, outputs.ModuleEnabled)
+}
+
+func TestDecodeOutputsJSON_TypeMismatch(t *testing.T) {
+	t.Parallel()
+
+	data := `{"module_enabled": {"sensitive": false, "type": "string", "value": "true"}}`
+
+	_, err := DecodeOutputsJSON[ProjectOutputs]([]byte(data))
+	assert.Error(t, err)
+}
+
+func TestDecodeOutputsJSON_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := DecodeOutputsJSON[ProjectOutputs]([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestSecret_Redacted(t *testing.T) {
+	t.Parallel()
+
+	secret := Secret("sb_secret_abc123")
+
+	assert.Equal(t, "[REDACTED]", secret.String())
+	assert.Equal(t, "[REDACTED]", fmt.Sprintf("%v", secret))
+	assert.Equal(t, "[REDACTED]", fmt.Sprintf("%#v", secret))
+	assert.NotContains(t, fmt.Sprintf("%+v", APIKeyOutputs{APIKey: secret}), "sb_secret_abc123")
+
+	data, err := json.Marshal(APIKeyOutputs{APIKey: secret})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "sb_secret_abc123")
+	assert.Equal(t, "sb_secret_abc123", secret.Reveal())
+}
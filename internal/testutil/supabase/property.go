@@ -0,0 +1,104 @@
+package supabase
+
+import (
+	"math/rand"
+	"reflect"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/property"
+)
+
+// propertyRegions are the regions drawn by the property generators
+var propertyRegions = []string{"us-east-1", "us-west-1", "eu-west-1", "eu-central-1", "ap-southeast-1", "ap-northeast-1"}
+
+// propertyInstanceSizes are the instance sizes accepted by Project.Validate
+var propertyInstanceSizes = []string{"nano", "micro", "small", "medium", "large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge"}
+
+// nameChars are the characters of generated project names
+const nameChars = property.Lower + property.Digits + "-"
+
+// QuickValues implements quick.Config.Values so testing/quick can produce valid projects, for properties taking
+// Project arguments only
+func QuickValues(args []reflect.Value, r *rand.Rand) {
+	for i := range args {
+		args[i] = reflect.ValueOf(*GenerateProject(r, property.DefaultMaxSize))
+	}
+}
+
+// GenerateProject returns a random valid project; size bounds the length of its strings
+func GenerateProject(r *rand.Rand, size int) *Project {
+	return &Project{
+		OrganizationID:   property.String(r, property.Lower, 20, 0),
+		DatabasePassword: property.String(r, property.Printable, 8, size),
+		Name:             faker.TestNamePrefix + property.String(r, nameChars, 1, size),
+		Region:           property.OneOf(r, propertyRegions),
+		InstanceSize:     property.OneOf(r, propertyInstanceSizes),
+	}
+}
+
+// ProjectGenerator generates valid projects and shrinks them to simpler valid ones
+func ProjectGenerator() property.Generator[*Project] {
+	return property.Generator[*Project]{
+		Generate: GenerateProject,
+		Shrink: property.Filter(shrinkProject, func(p *Project) bool {
+			return p.Validate() == nil
+		}),
+	}
+}
+
+// InvalidProjectGenerator generates projects breaking exactly one constraint of Project.Validate
+func InvalidProjectGenerator() property.Generator[*Project] {
+	breakers := []func(r *rand.Rand, size int, p *Project) *Project{
+		func(_ *rand.Rand, _ int, p *Project) *Project { return p.WithOrganizationID("") },
+		func(r *rand.Rand, _ int, p *Project) *Project {
+			return p.WithDatabasePassword(property.String(r, property.Printable, 0, 7))
+		},
+		func(_ *rand.Rand, _ int, p *Project) *Project { return p.WithName("") },
+		func(r *rand.Rand, size int, p *Project) *Project {
+			return p.WithName(property.String(r, nameChars, 257, size))
+		},
+		func(_ *rand.Rand, _ int, p *Project) *Project { return p.WithRegion("") },
+		func(r *rand.Rand, size int, p *Project) *Project {
+			return p.WithInstanceSize("x" + property.String(r, property.Lower, 0, size))
+		},
+	}
+	return property.Generator[*Project]{
+		Generate: func(r *rand.Rand, size int) *Project {
+			return property.OneOf(r, breakers)(r, size, GenerateProject(r, size))
+		},
+		Shrink: property.Filter(shrinkProject, func(p *Project) bool {
+			return len(violations(p)) == 1
+		}),
+	}
+}
+
+// shrinkProject proposes projects with one field simplified
+func shrinkProject(p *Project) []*Project {
+	candidates := []*Project{}
+	for _, s := range property.ShrinkString(p.OrganizationID, 0) {
+		candidates = append(candidates, p.WithOrganizationID(s))
+	}
+	for _, s := range property.ShrinkString(p.DatabasePassword, 0) {
+		candidates = append(candidates, p.WithDatabasePassword(s))
+	}
+	for _, s := range property.ShrinkString(p.Name, 0) {
+		candidates = append(candidates, p.WithName(s))
+	}
+	if p.Region != propertyRegions[0] {
+		candidates = append(candidates, p.WithRegion(propertyRegions[0]))
+	}
+	if p.InstanceSize != propertyInstanceSizes[0] {
+		candidates = append(candidates, p.WithInstanceSize(propertyInstanceSizes[0]))
+	}
+	return candidates
+}
+
+// violations returns the field violations reported by Project.Validate
+func violations(p *Project) []errors.FieldViolation {
+	ie := &errors.Error{}
+	if !errors.As(p.Validate(), &ie) {
+		return nil
+	}
+	return ie.FieldViolations()
+}
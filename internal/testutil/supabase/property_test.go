@@ -0,0 +1,129 @@
+package supabase
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/property"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
+)
+
+func TestProperty_ValidateAcceptsGenerated(t *testing.T) {
+	t.Parallel()
+
+	property.Check(t, ProjectGenerator(), func(p *Project) error {
+		return p.Validate()
+	}, nil)
+}
+
+func TestProperty_ValidateRejectsInvalid(t *testing.T) {
+	t.Parallel()
+
+	property.Check(t, InvalidProjectGenerator(), func(p *Project) error {
+		err := p.Validate()
+		if !errors.IsKind(err, errors.ErrorInvalidArgument) || len(violations(p)) != 1 {
+			return fmt.Errorf("want one field violation, got %v", violations(p))
+		}
+		return nil
+	}, nil)
+}
+
+func TestProperty_ToMapRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	property.Check(t, ProjectGenerator(), func(p *Project) error {
+		got, err := ProjectFromMap(p.ToMap())
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(p, got) {
+			return fmt.Errorf("round trip gave %+v", got)
+		}
+		return nil
+	}, &property.Config{Iterations: 30})
+}
+
+func TestProperty_TFVarsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	property.Check(t, ProjectGenerator(), func(p *Project) error {
+		data, err := p.ToTFVars()
+		if err != nil {
+			return err
+		}
+		vars, err := tfvars.Decode(data, "terraform.tfvars")
+		if err != nil {
+			return err
+		}
+		got, err := ProjectFromMap(vars)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(p, got) {
+			return fmt.Errorf("round trip gave %+v from\n%s", got, data)
+		}
+		return nil
+	}, &property.Config{Iterations: 30})
+}
+
+func TestProperty_WithNeverMutates(t *testing.T) {
+	t.Parallel()
+
+	builders := map[string]func(p, other *Project) *Project{
+		"WithOrganizationID":   func(p, other *Project) *Project { return p.WithOrganizationID(other.OrganizationID) },
+		"WithDatabasePassword": func(p, other *Project) *Project { return p.WithDatabasePassword(other.DatabasePassword) },
+		"WithName":             func(p, other *Project) *Project { return p.WithName(other.Name) },
+		"WithRegion":           func(p, other *Project) *Project { return p.WithRegion(other.Region) },
+		"WithInstanceSize":     func(p, other *Project) *Project { return p.WithInstanceSize(other.InstanceSize) },
+	}
+	gen := ProjectGenerator()
+	pairs := property.Generator[[2]*Project]{
+		Generate: func(r *rand.Rand, size int) [2]*Project {
+			return [2]*Project{gen.Generate(r, size), gen.Generate(r, size)}
+		},
+	}
+
+	property.Check(t, pairs, func(pair [2]*Project) error {
+		p, other := pair[0], pair[1]
+		for name, with := range builders {
+			before := *p
+			built := with(p, other)
+			if *p != before {
+				return fmt.Errorf("%s mutated the receiver", name)
+			}
+			if built == p {
+				return fmt.Errorf("%s returned the receiver", name)
+			}
+		}
+		return nil
+	}, nil)
+}
+
+func TestQuick_ValidateAcceptsGenerated(t *testing.T) {
+	t.Parallel()
+
+	err := quick.Check(func(p Project) bool {
+		return p.Validate() == nil
+	}, &quick.Config{Values: QuickValues})
+	assert.NoError(t, err)
+}
+
+func TestProperty_ShrinksInvalidProject(t *testing.T) {
+	t.Parallel()
+
+	failure := property.Run(InvalidProjectGenerator(), func(p *Project) error {
+		return p.Validate()
+	}, &property.Config{Seed: 1})
+	require.NotNil(t, failure)
+
+	require.Len(t, violations(failure.Shrunk), 1)
+	assert.LessOrEqual(t, len(failure.Shrunk.DatabasePassword), len(failure.Original.DatabasePassword))
+	assert.LessOrEqual(t, len(failure.Shrunk.Name), len(failure.Original.Name))
+}
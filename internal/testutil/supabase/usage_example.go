@@ -95,8 +95,8 @@ func ExampleUsage() {
 	fmt.Println("      terraformOptions.Vars[\"legacy_api_keys_enabled\"] = false")
 	fmt.Println("      ")
 	fmt.Println("      // Use in test (commented out for example)")
-	fmt.Println("      // defer terraform.Destroy(t, terraformOptions)")
-	fmt.Println("      // terraform.InitAndApply(t, terraformOptions)")
+	fmt.Println("      // defer ledger.Destroy(t, terraformOptions)")
+	fmt.Println("      // ledger.InitAndApply(t, terraformOptions)")
 	fmt.Println("      ")
 	fmt.Println("      // Verify values")
 	fmt.Println("      // assert.NotEmpty(t, terraformOptions.Vars[\"organization_id\"])")
@@ -2,6 +2,12 @@ package supabase
 
 import (
 	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/mirror"
 )
 
 // Default returns a new Project instance with default values
@@ -14,39 +20,62 @@ func DefaultWithFaker() *Project {
 	return NewProjectWithFaker()
 }
 
+// Fake returns a fixture of type T with every field filled from its faker tags, e.g. Fake[Project](t)
+func Fake[T any](t testing.TestingT) *T {
+	fixture := new(T)
+	require.NoError(t, faker.FakeData(fixture))
+	return fixture
+}
+
 // DefaultWithOrganizationID creates a new Project with a specific organization ID
 func DefaultWithOrganizationID(orgID string) *Project {
 	return NewProject().WithOrganizationID(orgID)
 }
 
+// WithProviderInstallation returns a copy of options installing providers the way the environment asks:
+// from the TF_PROVIDER_MIRROR filesystem mirror when set, and upgrading them only when TF_UPGRADE is true
+func WithProviderInstallation(options *terraform.Options) *terraform.Options {
+	conf := config.Must()
+	clone, err := options.Clone()
+	if err != nil {
+		panic(err)
+	}
+	clone.Upgrade = clone.Upgrade || conf.Terraform.Upgrade
+	if conf.Terraform.ProviderMirror == "" {
+		return clone
+	}
+	clone, err = mirror.Options(clone, conf.Terraform.ProviderMirror)
+	if err != nil {
+		panic(err)
+	}
+	return clone
+}
+
 // DefaultForModule creates Terraform options with default values for a specific module
 func DefaultForModule(moduleDir string) *terraform.Options {
 	project := Default()
-	return &terraform.Options{
+	return WithProviderInstallation(&terraform.Options{
 		TerraformDir: moduleDir,
-		Upgrade:      true,
 		Vars:         project.ToMap(),
-	}
+	})
 }
 
 // DefaultForModuleWithFaker creates Terraform options with faker-generated values
 func DefaultForModuleWithFaker(moduleDir string) *terraform.Options {
 	project := DefaultWithFaker()
-	return &terraform.Options{
+	return WithProviderInstallation(&terraform.Options{
 		TerraformDir: moduleDir,
-		Upgrade:      true,
 		Vars:         project.ToMap(),
-	}
+	})
 }
 
 // DefaultForModuleWithOrganizationID creates Terraform options with custom organization ID
 func DefaultForModuleWithOrganizationID(moduleDir, orgID string) *terraform.Options {
 	project := DefaultWithOrganizationID(orgID)
-	return &terraform.Options{
+	return WithProviderInstallation(&terraform.Options{
 		TerraformDir: moduleDir,
-		Upgrade:      true,
 		Vars:         project.ToMap(),
-	}
+	})
 }
 
 // MergeProjectValues merges project values with custom values, with custom values taking precedence
@@ -106,31 +135,30 @@ func MergeProjectValuesWithOrganizationID(orgID string, customValues map[string]
 // TerraformOptions creates Terraform options with merged project values
 func TerraformOptions(moduleDir string, customValues map[string]interface{}) *terraform.Options {
 	mergedValues := MergeProjectValues(customValues)
-	return &terraform.Options{
+	return WithProviderInstallation(&terraform.Options{
 		TerraformDir: moduleDir,
-		Upgrade:      true,
 		Vars:         mergedValues,
-	}
+	})
 }
 
 // TerraformOptionsWithFaker creates Terraform options with faker-generated merged values
 func TerraformOptionsWithFaker(moduleDir string, customValues map[string]interface{}) *terraform.Options {
 	mergedValues := MergeDefaultsWithFaker(customValues)
-	return &terraform.Options{
+	return WithProviderInstallation(&terraform.Options{
 		TerraformDir: moduleDir,
-		Upgrade:      true,
 		Vars:         mergedValues,
-	}
+	})
 }
 
 // TerraformOptionsWithOrganizationID creates Terraform options with specific organization ID
+// The options are not guarded: guard.InitAndApply and guard.Destroy refuse organizations outside
+// TF_GUARD_ORGANIZATIONS, a direct terraform.InitAndApply or terraform.Destroy does not
 func TerraformOptionsWithOrganizationID(moduleDir, orgID string, customValues map[string]interface{}) *terraform.Options {
 	mergedValues := MergeProjectValuesWithOrganizationID(orgID, customValues)
-	return &terraform.Options{
+	return WithProviderInstallation(&terraform.Options{
 		TerraformDir: moduleDir,
-		Upgrade:      true,
 		Vars:         mergedValues,
-	}
+	})
 }
 
 // GetOrganizationID returns the organization ID from a map of Terraform variables
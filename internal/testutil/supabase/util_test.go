@@ -1,10 +1,18 @@
 package supabase
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/mirror"
 )
 
 const (
@@ -36,12 +44,12 @@ func TestDefaultWithOrganizationID(t *testing.T) {
 }
 
 func TestDefaultForModule(t *testing.T) {
-	t.Parallel()
+	t.Setenv("TF_UPGRADE", "false")
 
 	options := DefaultForModule(moduleProjectDir)
 
 	assert.Equal(t, moduleProjectDir, options.TerraformDir)
-	assert.True(t, options.Upgrade)
+	assert.False(t, options.Upgrade)
 	assert.NotNil(t, options.Vars)
 	assert.Equal(t, "hadenlabs", options.Vars["organization_id"])
 	assert.NotEmpty(t, options.Vars["database_password"])
@@ -53,13 +61,13 @@ func TestDefaultForModule(t *testing.T) {
 }
 
 func TestDefaultForModuleWithOrganizationID(t *testing.T) {
-	t.Parallel()
+	t.Setenv("TF_UPGRADE", "false")
 
 	orgID := "test-organization"
 	options := DefaultForModuleWithOrganizationID(moduleProjectDir, orgID)
 
 	assert.Equal(t, moduleProjectDir, options.TerraformDir)
-	assert.True(t, options.Upgrade)
+	assert.False(t, options.Upgrade)
 	assert.NotNil(t, options.Vars)
 	assert.Equal(t, orgID, options.Vars["organization_id"])
 	assert.NotEmpty(t, options.Vars["database_password"])
@@ -127,7 +135,7 @@ func TestMergeProjectValuesWithOrganizationID(t *testing.T) {
 }
 
 func TestTerraformOptions(t *testing.T) {
-	t.Parallel()
+	t.Setenv("TF_UPGRADE", "false")
 
 	customValues := map[string]any{
 		"organization_id": "test-org",
@@ -138,7 +146,7 @@ func TestTerraformOptions(t *testing.T) {
 
 	assert.IsType(t, &terraform.Options{}, options)
 	assert.Equal(t, moduleProjectDir, options.TerraformDir)
-	assert.True(t, options.Upgrade)
+	assert.False(t, options.Upgrade)
 	assert.Equal(t, "test-org", options.Vars["organization_id"])
 	assert.Equal(t, "test-project", options.Vars["name"])
 	assert.NotEmpty(t, options.Vars["database_password"])
@@ -147,7 +155,7 @@ func TestTerraformOptions(t *testing.T) {
 }
 
 func TestTerraformOptionsWithOrganizationID(t *testing.T) {
-	t.Parallel()
+	t.Setenv("TF_UPGRADE", "false")
 
 	orgID := "custom-org-id"
 	customValues := map[string]any{
@@ -158,7 +166,7 @@ func TestTerraformOptionsWithOrganizationID(t *testing.T) {
 
 	assert.IsType(t, &terraform.Options{}, options)
 	assert.Equal(t, moduleProjectDir, options.TerraformDir)
-	assert.True(t, options.Upgrade)
+	assert.False(t, options.Upgrade)
 	assert.Equal(t, orgID, options.Vars["organization_id"])
 	assert.Equal(t, "test-project", options.Vars["name"])
 	assert.NotEmpty(t, options.Vars["database_password"])
@@ -343,12 +351,12 @@ func TestDefaultWithFaker(t *testing.T) {
 }
 
 func TestDefaultForModuleWithFaker(t *testing.T) {
-	t.Parallel()
+	t.Setenv("TF_UPGRADE", "false")
 
 	options := DefaultForModuleWithFaker(moduleProjectDir)
 
 	assert.Equal(t, moduleProjectDir, options.TerraformDir)
-	assert.True(t, options.Upgrade)
+	assert.False(t, options.Upgrade)
 	assert.NotNil(t, options.Vars)
 
 	// All fields should be faker-generated
@@ -384,7 +392,7 @@ func TestMergeDefaultsWithFaker(t *testing.T) {
 }
 
 func TestTerraformOptionsWithFaker(t *testing.T) {
-	t.Parallel()
+	t.Setenv("TF_UPGRADE", "false")
 
 	customValues := map[string]any{
 		"name":   "faker-custom-project",
@@ -395,7 +403,7 @@ func TestTerraformOptionsWithFaker(t *testing.T) {
 
 	assert.IsType(t, &terraform.Options{}, options)
 	assert.Equal(t, moduleProjectDir, options.TerraformDir)
-	assert.True(t, options.Upgrade)
+	assert.False(t, options.Upgrade)
 
 	// Custom values should be used
 	assert.Equal(t, "faker-custom-project", options.Vars["name"])
@@ -430,3 +438,40 @@ func TestIntegrationWithFaker(t *testing.T) {
 	assert.Equal(t, true, options2.Vars["legacy_api_keys_enabled"])
 	assert.NotEmpty(t, options2.Vars["organization_id"]) // Still faker-generated
 }
+
+func TestFake_Project(t *testing.T) {
+	t.Parallel()
+
+	project := Fake[Project](t)
+
+	assert.True(t, strings.HasPrefix(project.Name, faker.TestNamePrefix), project.Name)
+	assert.True(t, strings.HasPrefix(project.OrganizationID, "org-"), project.OrganizationID)
+	assert.NotEmpty(t, project.DatabasePassword)
+	assert.NotEmpty(t, project.Region)
+	assert.NotEmpty(t, project.InstanceSize)
+	assert.NotEqual(t, project, Fake[Project](t))
+}
+
+func TestFake_APIKey(t *testing.T) {
+	t.Parallel()
+
+	module, err := modules.Find(modules.MustRoot(), "apikey")
+	require.NoError(t, err)
+
+	assert.NoError(t, module.Validate(Fake[APIKey](t).ToMap()))
+}
+
+func TestWithProviderInstallation(t *testing.T) {
+	mirrorDir := t.TempDir()
+	t.Setenv("TF_PROVIDER_MIRROR", mirrorDir)
+	t.Setenv("TF_UPGRADE", "true")
+
+	options := DefaultForModule("modules/project")
+	assert.True(t, options.Upgrade)
+
+	path := options.EnvVars[mirror.CLIConfigEnv]
+	require.FileExists(t, path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), filepath.ToSlash(mirrorDir))
+}
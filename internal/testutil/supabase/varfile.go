@@ -0,0 +1,84 @@
+package supabase
+
+import (
+	"os"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
+)
+
+const (
+	// VarFilePattern is the temp file pattern of var files passed with -var-file
+	VarFilePattern = "terratest-*.tfvars.json"
+
+	// AutoVarFilePattern is the temp file pattern of var files Terraform loads on its own
+	AutoVarFilePattern = "terratest-*.auto.tfvars.json"
+)
+
+// CleanupT is a testing.TestingT able to run cleanup functions, such as *testing.T
+type CleanupT interface {
+	testing.TestingT
+	Cleanup(func())
+}
+
+// WriteVarFileE writes vars to a new file in dir, named after pattern as os.CreateTemp does
+// Files ending in .json are written as JSON, anything else as HCL
+// The file is readable by the current user only since it usually holds secrets
+func WriteVarFileE(dir, pattern string, vars map[string]interface{}) (string, error) {
+	data, err := tfvars.EncodeFormat(vars, tfvars.FormatOf(pattern))
+	if err != nil {
+		return "", err
+	}
+	file, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return "", errors.Wrapf(err, errors.ErrorUnknown, "create var file in %s", dir)
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return "", errors.Wrapf(err, errors.ErrorUnknown, "write %s", file.Name())
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return "", errors.Wrapf(err, errors.ErrorUnknown, "close %s", file.Name())
+	}
+	return file.Name(), nil
+}
+
+// WriteVarFile writes vars to a new file in dir and removes it when the test finishes
+func WriteVarFile(t CleanupT, dir, pattern string, vars map[string]interface{}) string {
+	filename, err := WriteVarFileE(dir, pattern, vars)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.Remove(filename) })
+	return filename
+}
+
+// WriteAutoVarFile writes vars to a *.auto.tfvars.json file in moduleDir, which Terraform
+// loads without any -var-file argument, and removes it when the test finishes
+func WriteAutoVarFile(t CleanupT, moduleDir string, vars map[string]interface{}) string {
+	return WriteVarFile(t, moduleDir, AutoVarFilePattern, vars)
+}
+
+// WithVarFile returns a copy of options whose Vars are moved into a temporary var file
+// listed in VarFiles, so values such as database_password never appear in process arguments
+// Terratest passes Vars as -var flags, which ps shows and its logger prints with every command
+func WithVarFile(t CleanupT, options *terraform.Options) *terraform.Options {
+	clone, err := options.Clone()
+	require.NoError(t, err)
+	if len(clone.Vars) == 0 {
+		return clone
+	}
+	filename := WriteVarFile(t, "", VarFilePattern, clone.Vars)
+	clone.VarFiles = append(clone.VarFiles, filename)
+	clone.Vars = map[string]interface{}{}
+	return clone
+}
+
+// DefaultForModuleWithVarFile creates Terraform options with default values passed through a var file
+func DefaultForModuleWithVarFile(t CleanupT, moduleDir string) *terraform.Options {
+	return WithVarFile(t, DefaultForModule(moduleDir))
+}
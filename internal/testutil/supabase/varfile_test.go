@@ -0,0 +1,52 @@
+package supabase
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/tfvars"
+)
+
+func TestWriteVarFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	var filename string
+	t.Run("write", func(t *testing.T) {
+		filename = WriteAutoVarFile(t, dir, NewProject().ToMap())
+
+		assert.Equal(t, dir, filepath.Dir(filename))
+		assert.True(t, strings.HasSuffix(filename, ".auto.tfvars.json"))
+		info, err := os.Stat(filename)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+	assert.NoFileExists(t, filename, "var file should be removed once the test finishes")
+}
+
+func TestWithVarFile(t *testing.T) {
+	t.Parallel()
+
+	project := NewProject().WithDatabasePassword("do-not-leak")
+	options := &terraform.Options{TerraformDir: "project-basic", Vars: project.ToMap()}
+
+	withFile := WithVarFile(t, options)
+
+	assert.Equal(t, project.ToMap(), options.Vars, "original options should be untouched")
+	assert.Empty(t, withFile.Vars)
+	require.Len(t, withFile.VarFiles, 1)
+
+	vars, err := tfvars.Read(withFile.VarFiles[0])
+	require.NoError(t, err)
+	assert.Equal(t, project.ToMap(), vars)
+
+	args := terraform.FormatArgs(withFile, "plan")
+	assert.NotContains(t, strings.Join(args, " "), "do-not-leak")
+	assert.Contains(t, args, "-var-file")
+}
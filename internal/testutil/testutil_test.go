@@ -24,26 +24,26 @@ func TestDefaultWithOrganizationID(t *testing.T) {
 }
 
 func TestDefaultForModule(t *testing.T) {
-	t.Parallel()
+	t.Setenv("TF_UPGRADE", "false")
 
 	moduleDir := "modules/project"
 	options := DefaultForModule(moduleDir)
 
 	assert.Equal(t, moduleDir, options.TerraformDir)
-	assert.True(t, options.Upgrade)
+	assert.False(t, options.Upgrade)
 	assert.NotNil(t, options.Vars)
 	assert.Equal(t, "hadenlabs", options.Vars["organization_id"])
 }
 
 func TestDefaultForModuleWithOrganizationID(t *testing.T) {
-	t.Parallel()
+	t.Setenv("TF_UPGRADE", "false")
 
 	moduleDir := "modules/project"
 	orgID := "test-organization"
 	options := DefaultForModuleWithOrganizationID(moduleDir, orgID)
 
 	assert.Equal(t, moduleDir, options.TerraformDir)
-	assert.True(t, options.Upgrade)
+	assert.False(t, options.Upgrade)
 	assert.NotNil(t, options.Vars)
 	assert.Equal(t, orgID, options.Vars["organization_id"])
 }
@@ -91,7 +91,7 @@ func TestMergeProjectValuesWithOrganizationID(t *testing.T) {
 }
 
 func TestTerraformOptions(t *testing.T) {
-	t.Parallel()
+	t.Setenv("TF_UPGRADE", "false")
 
 	moduleDir := "modules/project"
 	customValues := map[string]interface{}{
@@ -103,13 +103,13 @@ func TestTerraformOptions(t *testing.T) {
 
 	assert.IsType(t, &terraform.Options{}, options)
 	assert.Equal(t, moduleDir, options.TerraformDir)
-	assert.True(t, options.Upgrade)
+	assert.False(t, options.Upgrade)
 	assert.Equal(t, "test-org", options.Vars["organization_id"])
 	assert.Equal(t, "test-project", options.Vars["name"])
 }
 
 func TestTerraformOptionsWithOrganizationID(t *testing.T) {
-	t.Parallel()
+	t.Setenv("TF_UPGRADE", "false")
 
 	moduleDir := "modules/project"
 	orgID := "custom-org-id"
@@ -121,7 +121,7 @@ func TestTerraformOptionsWithOrganizationID(t *testing.T) {
 
 	assert.IsType(t, &terraform.Options{}, options)
 	assert.Equal(t, moduleDir, options.TerraformDir)
-	assert.True(t, options.Upgrade)
+	assert.False(t, options.Upgrade)
 	assert.Equal(t, orgID, options.Vars["organization_id"])
 	assert.Equal(t, "test-project", options.Vars["name"])
 }
@@ -0,0 +1,95 @@
+package upgrade
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/shell"
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+// PreviousTag returns the closest tag reachable from HEAD in the repository at root, the release
+// consumers upgrade from
+func PreviousTag(t testing.TestingT, root string) (string, error) {
+	out, err := shell.RunCommandAndGetStdOutE(t, shell.Command{
+		Command:    "git",
+		Args:       []string{"describe", "--tags", "--abbrev=0"},
+		WorkingDir: root,
+		Logger:     logger.Discard,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, errors.ErrorNotFound, "no tag in %s", root)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Checkout extracts modules/ as of the git ref into dest, keeping the relative sources between fixtures
+// and modules
+func Checkout(t testing.TestingT, root, ref, dest string) error {
+	archive := filepath.Join(dest, "checkout.tar")
+	err := shell.RunCommandE(t, shell.Command{
+		Command:    "git",
+		Args:       []string{"archive", "--format=tar", "-o", archive, ref, modules.ModulesDir},
+		WorkingDir: root,
+		Logger:     logger.Discard,
+	})
+	if err != nil {
+		return errors.Wrapf(err, errors.ErrorNotFound, "archive %s at %s", modules.ModulesDir, ref)
+	}
+	defer os.Remove(archive)
+	return extract(archive, dest)
+}
+
+func extract(archive, dest string) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return errors.Wrapf(err, errors.ErrorUnknown, "open %s", archive)
+	}
+	defer file.Close()
+
+	reader := tar.NewReader(file)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, errors.ErrorUnknown, "read %s", archive)
+		}
+		target := filepath.Join(dest, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dest)+string(filepath.Separator)) {
+			return errors.Errorf(errors.ErrorInvalidArgument, "%s escapes %s", header.Name, dest)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0o755)
+		case tar.TypeReg:
+			err = writeFile(target, reader)
+		}
+		if err != nil {
+			return errors.Wrapf(err, errors.ErrorUnknown, "extract %s", header.Name)
+		}
+	}
+}
+
+func writeFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, r); err != nil { //nolint:gosec
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,106 @@
+package upgrade
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/shell"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+const fixtureConfig = `module "supabase_project" {
+  source = "../.."
+
+  name = var.name
+}
+
+module "registry" {
+  source  = "hadenlabs/supabase/project"
+  version = "0.1.0"
+}
+`
+
+// repository creates a git repository holding modules/project and its basic fixture
+func repository(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	write(t, filepath.Join(root, modules.ModulesDir, "project", "main.tf"), `resource "supabase_project" "this" {}`+"\n")
+	write(t, filepath.Join(root, modules.ModulesDir, "project", "test", "basic", "main.tf"), fixtureConfig)
+	git(t, root, "init", "--quiet")
+	git(t, root, "add", ".")
+	git(t, root, "commit", "--quiet", "-m", "initial")
+	return root
+}
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	shell.RunCommand(t, shell.Command{
+		Command:    "git",
+		Args:       append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...),
+		WorkingDir: dir,
+		Logger:     logger.Discard,
+	})
+}
+
+func TestPreviousTag(t *testing.T) {
+	t.Parallel()
+
+	root := repository(t)
+	_, err := PreviousTag(t, root)
+	assert.True(t, errors.IsKind(err, errors.ErrorNotFound))
+
+	git(t, root, "tag", "v0.1.0")
+	tag, err := PreviousTag(t, root)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.1.0", tag)
+}
+
+func TestCheckout(t *testing.T) {
+	t.Parallel()
+
+	root := repository(t)
+	git(t, root, "tag", "v0.1.0")
+	write(t, filepath.Join(root, modules.ModulesDir, "project", "main.tf"), `resource "supabase_project" "renamed" {}`+"\n")
+
+	dest := t.TempDir()
+	require.NoError(t, Checkout(t, root, "v0.1.0", dest))
+	data, err := os.ReadFile(filepath.Join(dest, modules.ModulesDir, "project", "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, `resource "supabase_project" "this" {}`+"\n", string(data))
+	assert.NoFileExists(t, filepath.Join(dest, "checkout.tar"))
+
+	err = Checkout(t, root, "v9.9.9", t.TempDir())
+	assert.True(t, errors.IsKind(err, errors.ErrorNotFound))
+}
+
+func TestSwitchSource(t *testing.T) {
+	t.Parallel()
+
+	root := repository(t)
+	git(t, root, "tag", "v0.1.0")
+	previous := t.TempDir()
+	require.NoError(t, Checkout(t, root, "v0.1.0", previous))
+
+	fixture := filepath.Join(previous, modules.ModulesDir, "project", "test", "basic")
+	switched, err := SwitchSource(fixture, previous, root)
+	require.NoError(t, err)
+	assert.Equal(t, 1, switched, "registry sources are left alone")
+
+	module, err := modules.Load(fixture)
+	require.NoError(t, err)
+	require.Len(t, module.Calls, 2)
+	assert.Equal(t, filepath.ToSlash(filepath.Join(root, modules.ModulesDir, "project")), module.Calls[0].Source)
+	assert.Equal(t, "hadenlabs/supabase/project", module.Calls[1].Source)
+}
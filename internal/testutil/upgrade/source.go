@@ -0,0 +1,85 @@
+package upgrade
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+)
+
+// SwitchSource points every local module call of the configuration at dir resolving inside from at the
+// same path inside to, e.g. from the previous release to the working tree. It returns the number of calls
+// switched.
+func SwitchSource(dir, from, to string) (int, error) {
+	module, err := modules.Load(dir)
+	if err != nil {
+		return 0, err
+	}
+	from, err = filepath.Abs(from)
+	if err != nil {
+		return 0, errors.Wrapf(err, errors.ErrorInvalidArgument, "resolve %s", from)
+	}
+	to, err = filepath.Abs(to)
+	if err != nil {
+		return 0, errors.Wrapf(err, errors.ErrorInvalidArgument, "resolve %s", to)
+	}
+
+	switched := map[string]map[string]string{}
+	for _, call := range module.Calls {
+		if !local(call.Source) {
+			continue
+		}
+		rel, err := filepath.Rel(from, filepath.Join(module.Dir, filepath.FromSlash(call.Source)))
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		if switched[call.Range.Filename] == nil {
+			switched[call.Range.Filename] = map[string]string{}
+		}
+		switched[call.Range.Filename][call.Name] = filepath.ToSlash(filepath.Join(to, rel))
+	}
+
+	count := 0
+	for filename, sources := range switched {
+		if err := rewrite(filename, sources); err != nil {
+			return count, err
+		}
+		count += len(sources)
+	}
+	return count, nil
+}
+
+// rewrite sets the source of the module blocks of filename named in sources.
+func rewrite(filename string, sources map[string]string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return errors.Wrapf(err, errors.ErrorUnknown, "read %s", filename)
+	}
+	file, diags := hclwrite.ParseConfig(data, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return errors.Wrapf(diags, errors.ErrorInvalidArgument, "parse %s", filename)
+	}
+	for _, block := range file.Body().Blocks() {
+		if block.Type() != "module" || len(block.Labels()) != 1 {
+			continue
+		}
+		if source, ok := sources[block.Labels()[0]]; ok {
+			block.Body().SetAttributeValue("source", cty.StringVal(source))
+		}
+	}
+	if err := os.WriteFile(filename, file.Bytes(), 0o600); err != nil {
+		return errors.Wrapf(err, errors.ErrorUnknown, "write %s", filename)
+	}
+	return nil
+}
+
+// local reports whether source is a local path, which terraform reads in place instead of downloading
+func local(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../")
+}
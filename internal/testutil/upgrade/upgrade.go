@@ -0,0 +1,127 @@
+package upgrade
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	gotesting "github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/modules"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/drift"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/ledger"
+)
+
+// ProtectedTypes are the resource types an upgrade must never replace or destroy, replacing a project
+// recreates its database
+var ProtectedTypes = []string{"supabase_project"}
+
+// Run applies options.TerraformDir, which must live under modules/, as of the git ref from, switches its
+// module sources to the working tree and plans. It fails when the plan replaces or destroys a resource of
+// ProtectedTypes and returns the changes. An empty from is the previous tag, the test is skipped without one.
+func Run(t *testing.T, options *terraform.Options, from string) *drift.Changes {
+	t.Helper()
+
+	root, err := modules.Root()
+	require.NoError(t, err)
+	if from == "" {
+		if from, err = PreviousTag(t, root); err != nil {
+			t.Skipf("no previous release to upgrade from: %v", err)
+		}
+	}
+
+	dir, err := filepath.Abs(options.TerraformDir)
+	require.NoError(t, err)
+	rel, err := filepath.Rel(filepath.Join(root, modules.ModulesDir), dir)
+	require.NoError(t, err)
+	require.False(t, strings.HasPrefix(rel, ".."), "%s is not under %s", dir, modules.ModulesDir)
+
+	previous := t.TempDir()
+	require.NoError(t, Checkout(t, root, from, previous))
+	fixture := filepath.Join(previous, modules.ModulesDir, rel)
+	if _, err := os.Stat(fixture); err != nil {
+		t.Skipf("%s does not exist at %s", filepath.ToSlash(rel), from)
+	}
+
+	upgraded, err := options.Clone()
+	require.NoError(t, err)
+	upgraded.TerraformDir = fixture
+
+	// Destroy runs after the switch, with the module of the working tree
+	defer ledger.Destroy(t, upgraded)
+	ledger.InitAndApply(t, upgraded)
+
+	switched, err := SwitchSource(fixture, previous, root)
+	require.NoError(t, err)
+	require.NotZero(t, switched, "%s calls no local module", filepath.ToSlash(rel))
+	terraform.Init(t, upgraded)
+
+	changes := drift.Plan(t, upgraded)
+	t.Logf("upgrade from %s:\n%s", from, changes)
+	AssertNoReplacement(t, changes, ProtectedTypes...)
+	return changes
+}
+
+// Replacements returns the changes replacing or destroying a resource of one of types
+func Replacements(changes *drift.Changes, types ...string) []drift.ResourceChange {
+	replaced := []drift.ResourceChange{}
+	for _, change := range changes.Replaced() {
+		for _, typ := range types {
+			if ResourceType(change.Address) == typ {
+				replaced = append(replaced, change)
+				break
+			}
+		}
+	}
+	return replaced
+}
+
+// AssertNoReplacement fails when changes replace or destroy a resource of one of types, explaining why
+func AssertNoReplacement(t gotesting.TestingT, changes *drift.Changes, types ...string) bool {
+	replaced := Replacements(changes, types...)
+	explanations := make([]string, 0, len(replaced))
+	for _, change := range replaced {
+		explanations = append(explanations, Explain(change))
+	}
+	return assert.Empty(t, replaced, "upgrade is destructive:\n%s", strings.Join(explanations, "\n"))
+}
+
+// Explain tells why change destroys its resource, listing the attributes forcing a replacement
+func Explain(change drift.ResourceChange) string {
+	if change.Action == drift.ActionDelete {
+		return fmt.Sprintf("%s would be destroyed, its address is gone from the configuration, add a moved block",
+			change.Address)
+	}
+	var b strings.Builder
+	forced := false
+	for _, attr := range change.Attributes {
+		if attr.ForcesReplacement {
+			if !forced {
+				fmt.Fprintf(&b, "%s would be replaced, forced by:", change.Address)
+				forced = true
+			}
+			fmt.Fprintf(&b, "\n    %s", attr)
+		}
+	}
+	if !forced {
+		return fmt.Sprintf("%s would be replaced, terraform reports no attribute forcing it", change.Address)
+	}
+	return b.String()
+}
+
+// ResourceType returns the resource type of an address such as module.supabase_project.supabase_project.this[0]
+func ResourceType(address string) string {
+	if i := strings.LastIndex(address, "["); i > 0 && strings.HasSuffix(address, "]") {
+		address = address[:i]
+	}
+	parts := strings.Split(address, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[len(parts)-2]
+}
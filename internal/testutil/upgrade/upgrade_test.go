@@ -0,0 +1,76 @@
+package upgrade
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/drift"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/recorder"
+)
+
+func readPlan(t *testing.T) *drift.Changes {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "plan.json"))
+	require.NoError(t, err)
+	changes, err := drift.ParsePlan(data)
+	require.NoError(t, err)
+	return changes
+}
+
+func TestResourceType(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"supabase_project.this":                                   "supabase_project",
+		"supabase_project.this[0]":                                "supabase_project",
+		"module.supabase_project.supabase_project.this[0]":        "supabase_project",
+		`module.stack["a.b"].supabase_apikey.this["ci"]`:          "supabase_apikey",
+		"module.outer.module.inner.data.supabase_apikeys.this[1]": "supabase_apikeys",
+		"this": "",
+	}
+	for address, want := range cases {
+		assert.Equal(t, want, ResourceType(address), address)
+	}
+}
+
+func TestReplacements(t *testing.T) {
+	t.Parallel()
+
+	changes := readPlan(t)
+	replaced := Replacements(changes, ProtectedTypes...)
+	require.Len(t, replaced, 2)
+	assert.Equal(t, "module.supabase_project.supabase_project.this[0]", replaced[0].Address)
+	assert.Equal(t, "supabase_project.legacy", replaced[1].Address)
+
+	assert.Empty(t, Replacements(changes, "supabase_settings"), "updates are not replacements")
+}
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	replaced := Replacements(readPlan(t), ProtectedTypes...)
+	require.Len(t, replaced, 2)
+	assert.Equal(t, "module.supabase_project.supabase_project.this[0] would be replaced, forced by:\n"+
+		`    region: "us-east-1" -> "eu-west-1" # forces replacement`, Explain(replaced[0]))
+	assert.Equal(t, "supabase_project.legacy would be destroyed, its address is gone from the configuration, "+
+		"add a moved block", Explain(replaced[1]))
+
+	unexplained := drift.ResourceChange{Address: "supabase_project.this", Action: drift.ActionReplace}
+	assert.Equal(t, "supabase_project.this would be replaced, terraform reports no attribute forcing it",
+		Explain(unexplained))
+}
+
+func TestAssertNoReplacement(t *testing.T) {
+	t.Parallel()
+
+	changes := readPlan(t)
+	assert.True(t, AssertNoReplacement(t, changes, "supabase_settings"))
+
+	r := recorder.New(t)
+	assert.False(t, AssertNoReplacement(r, changes, ProtectedTypes...))
+	assert.True(t, r.Failed, "replacing a project must fail the test")
+}
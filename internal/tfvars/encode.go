@@ -0,0 +1,50 @@
+package tfvars
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// Encode renders variables as a terraform.tfvars file with sorted keys.
+func Encode(vars map[string]interface{}) ([]byte, error) {
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+	for _, name := range sortedKeys(vars) {
+		value, err := ToCty(vars[name])
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.ErrorInvalidArgument, "encode variable %s", name)
+		}
+		body.SetAttributeValue(name, value)
+	}
+	return hclwrite.Format(file.Bytes()), nil
+}
+
+// EncodeJSON renders variables as a .tfvars.json file.
+func EncodeJSON(vars map[string]interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(vars, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorInvalidArgument, "encode variables")
+	}
+	return append(data, '\n'), nil
+}
+
+// EncodeFormat renders variables in the given format.
+func EncodeFormat(vars map[string]interface{}, format Format) ([]byte, error) {
+	if format == FormatJSON {
+		return EncodeJSON(vars)
+	}
+	return Encode(vars)
+}
+
+func sortedKeys(vars map[string]interface{}) []string {
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,62 @@
+package tfvars
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	data, err := Encode(map[string]interface{}{
+		"name":           "tftest-project",
+		"module_enabled": true,
+		"description":    nil,
+		"labels":         map[string]interface{}{"team": "platform"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `description = null
+labels = {
+  team = "platform"
+}
+module_enabled = true
+name           = "tftest-project"
+`, string(data))
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]interface{}{
+		"name":           "tftest-project",
+		"instance_size":  "micro",
+		"module_enabled": false,
+		"retries":        float64(3),
+		"regions":        []interface{}{"us-east-1", "eu-west-1"},
+		"description":    nil,
+	}
+
+	for _, format := range []Format{FormatHCL, FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			data, err := EncodeFormat(vars, format)
+			require.NoError(t, err)
+
+			filename := filepath.Join(t.TempDir(), "terraform.tfvars")
+			if format == FormatJSON {
+				filename += ".json"
+			}
+			require.NoError(t, os.WriteFile(filename, data, 0o600))
+
+			got, err := Read(filename)
+			require.NoError(t, err)
+			assert.Equal(t, vars, got)
+		})
+	}
+}
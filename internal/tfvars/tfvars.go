@@ -0,0 +1,106 @@
+package tfvars
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// Format is the syntax of a variable definitions file.
+type Format string
+
+// Supported formats.
+const (
+	FormatHCL  Format = "hcl"
+	FormatJSON Format = "json"
+)
+
+// FormatOf returns the format Terraform infers from a file name.
+func FormatOf(filename string) Format {
+	if strings.HasSuffix(filename, ".json") {
+		return FormatJSON
+	}
+	return FormatHCL
+}
+
+// Read parses a .tfvars or .tfvars.json file.
+func Read(filename string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.ErrorNotFound, "read %s", filename)
+	}
+	return Decode(data, filename)
+}
+
+// Decode parses variable definitions, using filename to pick the syntax.
+func Decode(data []byte, filename string) (map[string]interface{}, error) {
+	parser := hclparse.NewParser()
+	var file *hcl.File
+	var diags hcl.Diagnostics
+	if FormatOf(filename) == FormatJSON {
+		file, diags = parser.ParseJSON(data, filepath.Base(filename))
+	} else {
+		file, diags = parser.ParseHCL(data, filepath.Base(filename))
+	}
+	if diags.HasErrors() {
+		return nil, errors.Wrapf(diags, errors.ErrorInvalidArgument, "parse %s", filename)
+	}
+
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, errors.Wrapf(diags, errors.ErrorInvalidArgument, "parse %s", filename)
+	}
+	vars := make(map[string]interface{}, len(attrs))
+	for name, attr := range attrs {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, errors.Wrapf(diags, errors.ErrorInvalidArgument, "evaluate %s in %s", name, filename)
+		}
+		goValue, err := ToGo(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.ErrorInvalidArgument, "convert %s in %s", name, filename)
+		}
+		vars[name] = goValue
+	}
+	return vars, nil
+}
+
+// ToCty converts a Go value, as produced by encoding/json, into a cty value.
+func ToCty(v interface{}) (cty.Value, error) {
+	if v == nil {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	ty, err := ctyjson.ImpliedType(data)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return ctyjson.Unmarshal(data, ty)
+}
+
+// ToGo converts a cty value into the value encoding/json would produce for it.
+func ToGo(value cty.Value) (interface{}, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	data, err := ctyjson.Marshal(value, value.Type())
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
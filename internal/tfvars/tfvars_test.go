@@ -0,0 +1,27 @@
+package tfvars
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+func TestDecode_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := Decode([]byte(`name = `), "terraform.tfvars")
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+
+	_, err = Decode([]byte(`name = var.other`), "terraform.tfvars")
+	assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument))
+}
+
+func TestRead_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := Read(filepath.Join(t.TempDir(), "missing.tfvars"))
+	assert.True(t, errors.IsKind(err, errors.ErrorNotFound))
+}
@@ -0,0 +1,92 @@
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// develVersion is the main module version reported by `go build` outside of a module download
+const develVersion = "(devel)"
+
+// BuildInfo describes how the binary was built
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	Modified  bool   `json:"modified"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// newBuildInfo fills the values not provisioned by ldflags from the build information embedded by the go toolchain
+func newBuildInfo(version, commit, date string, info *debug.BuildInfo) BuildInfo {
+	b := BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if info == nil {
+		return b
+	}
+	if b.Version == dev && info.Main.Version != "" && info.Main.Version != develVersion {
+		b.Version = strings.TrimPrefix(info.Main.Version, "v")
+	}
+	if info.GoVersion != "" {
+		b.GoVersion = info.GoVersion
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if b.Commit == dev {
+				b.Commit = setting.Value
+			}
+		case "vcs.time":
+			if b.Date == "" {
+				b.Date = setting.Value
+			}
+		case "vcs.modified":
+			b.Modified = setting.Value == "true"
+		}
+	}
+	return b
+}
+
+// String renders the build information as the version, the commit hash, the platform and the build date
+func (b BuildInfo) String() string {
+	commit := b.Commit
+	if b.Modified {
+		commit += "-dirty"
+	}
+	return fmt.Sprintf("%s %s %s BuildDate: %s", b.Version, commit, b.Platform, b.Date)
+}
+
+// Semver parses the version of the build
+func (b BuildInfo) Semver() (Semver, error) {
+	return Parse(b.Version)
+}
+
+// AtLeast reports whether the version of the build is minimum or newer
+func (b BuildInfo) AtLeast(minimum string) (bool, error) {
+	current, err := b.Semver()
+	if err != nil {
+		return false, err
+	}
+	required, err := Parse(minimum)
+	if err != nil {
+		return false, err
+	}
+	return current.Compare(required) >= 0, nil
+}
+
+// WriteJSON writes the build information as indented JSON
+func (b BuildInfo) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
@@ -0,0 +1,119 @@
+package version
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	info := &debug.BuildInfo{
+		GoVersion: "go1.24.0",
+		Main:      debug.Module{Version: "v1.4.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "8bf42d1c"},
+			{Key: "vcs.time", Value: "2026-01-02T03:04:05Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+
+	cases := []struct {
+		name    string
+		version string
+		commit  string
+		date    string
+		info    *debug.BuildInfo
+		want    BuildInfo
+	}{
+		{
+			name:    "ldflags",
+			version: "1.2.3",
+			commit:  "abcdef0",
+			date:    "2026-10-19T00:00:00Z",
+			info:    info,
+			want:    BuildInfo{Version: "1.2.3", Commit: "abcdef0", Date: "2026-10-19T00:00:00Z", Modified: true},
+		},
+		{
+			name:    "build info fallback",
+			version: dev,
+			commit:  dev,
+			info:    info,
+			want:    BuildInfo{Version: "1.4.0", Commit: "8bf42d1c", Date: "2026-01-02T03:04:05Z", Modified: true},
+		},
+		{
+			name:    "devel module",
+			version: dev,
+			commit:  dev,
+			info:    &debug.BuildInfo{Main: debug.Module{Version: develVersion}},
+			want:    BuildInfo{Version: dev, Commit: dev},
+		},
+		{
+			name:    "no build info",
+			version: dev,
+			commit:  dev,
+			want:    BuildInfo{Version: dev, Commit: dev},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := newBuildInfo(tc.version, tc.commit, tc.date, tc.info)
+			assert.NotEmpty(t, got.GoVersion)
+			assert.NotEmpty(t, got.Platform)
+			got.GoVersion, got.Platform = "", ""
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFull_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	first := Full()
+	assert.Equal(t, first, Full())
+	assert.Equal(t, Get().Version, Short())
+}
+
+func TestBuildInfo_String(t *testing.T) {
+	t.Parallel()
+
+	b := BuildInfo{Version: "1.2.3", Commit: "abcdef0", Date: "2026-10-19T00:00:00Z", Modified: true, Platform: "linux/amd64"}
+	assert.Equal(t, "1.2.3 abcdef0-dirty linux/amd64 BuildDate: 2026-10-19T00:00:00Z", b.String())
+}
+
+func TestBuildInfo_WriteJSON(t *testing.T) {
+	t.Parallel()
+
+	b := BuildInfo{Version: "1.2.3", Commit: "abcdef0", GoVersion: "go1.24.0", Platform: "linux/amd64"}
+	var buf bytes.Buffer
+	require.NoError(t, b.WriteJSON(&buf))
+
+	got := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "1.2.3", got["version"])
+	assert.Equal(t, "go1.24.0", got["go_version"])
+	assert.Equal(t, false, got["modified"])
+}
+
+func TestBuildInfo_AtLeast(t *testing.T) {
+	t.Parallel()
+
+	b := BuildInfo{Version: "1.2.3"}
+	ok, err := b.AtLeast("1.2.0")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = b.AtLeast("v1.3.0")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = b.AtLeast("one")
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+// semverPattern is the semantic versioning 2.0.0 grammar, with an optional leading v
+var semverPattern = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// Semver is a semantic version
+type Semver struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Build      string
+}
+
+// Parse parses a semantic version such as 1.2.3, v1.2.3-rc.1 or 1.2.3+build.5
+func Parse(s string) (Semver, error) {
+	match := semverPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return Semver{}, errors.Errorf(errors.ErrorInvalidArgument, "invalid semantic version %q", s)
+	}
+	v := Semver{Prerelease: match[4], Build: match[5]}
+	for i, field := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		n, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return Semver{}, errors.Wrapf(err, errors.ErrorInvalidArgument, "invalid semantic version %q", s)
+		}
+		*field = n
+	}
+	return v, nil
+}
+
+// MustParse is like Parse but panics on an invalid version
+func MustParse(s string) Semver {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String renders the version without a leading v
+func (v Semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 as v precedes, equals or follows other. Build metadata is ignored.
+func (v Semver) Compare(other Semver) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if c := compareInt(pair[0], pair[1]); c != 0 {
+			return c
+		}
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// LessThan reports whether v precedes other
+func (v Semver) LessThan(other Semver) bool {
+	return v.Compare(other) < 0
+}
+
+// Compare parses and compares two semantic versions, see Semver.Compare
+func Compare(a, b string) (int, error) {
+	va, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+// AtLeast reports whether the running binary is minimum or newer
+func AtLeast(minimum string) (bool, error) {
+	return Get().AtLeast(minimum)
+}
+
+// comparePrerelease orders prerelease identifiers; a version without one follows any version with one
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareInt(an, bn)
+		case aErr == nil:
+			c = -1
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(as[i], bs[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(as), len(bs))
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
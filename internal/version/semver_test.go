@@ -0,0 +1,56 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/internal/errors"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	v, err := Parse("v1.2.3-rc.1+build.5")
+	require.NoError(t, err)
+	assert.Equal(t, Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Build: "build.5"}, v)
+	assert.Equal(t, "1.2.3-rc.1+build.5", v.String())
+
+	for _, invalid := range []string{"", "1.2", "1.2.3.4", "01.2.3", "1.2.3-", "1.2.3-01", "latest"} {
+		_, err := Parse(invalid)
+		assert.True(t, errors.IsKind(err, errors.ErrorInvalidArgument), invalid)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	// ordered as in the semantic versioning specification
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			got, err := Compare(ordered[i], ordered[j])
+			require.NoError(t, err)
+			assert.Equal(t, compareInt(i, j), got, "%s <=> %s", ordered[i], ordered[j])
+		}
+	}
+
+	assert.False(t, MustParse("1.0.0+a").LessThan(MustParse("1.0.0+b")))
+	assert.True(t, MustParse("1.9.0").LessThan(MustParse("1.10.0")))
+
+	_, err := Compare("1.0.0", "x")
+	assert.Error(t, err)
+}
@@ -1,9 +1,7 @@
 package version
 
 import (
-	"fmt"
-	"runtime"
-	"strings"
+	"runtime/debug"
 	"time"
 )
 
@@ -28,21 +26,27 @@ func init() {
 	if commitHash == "" {
 		commitHash = dev
 	}
-	if buildDate == "" {
-		buildDate = time.Now().Format(time.RFC3339)
+	info, _ := debug.ReadBuildInfo()
+	current = newBuildInfo(version, commitHash, buildDate, info)
+	if current.Date == "" {
+		current.Date = time.Now().Format(time.RFC3339)
 	}
 }
 
+// current is the build information of the running binary
+var current BuildInfo
+
+// Get returns the build information of the running binary
+func Get() BuildInfo {
+	return current
+}
+
 // Short return the version of the binary
 func Short() string {
-	return version
+	return current.Version
 }
 
 // Full return the full version of the binary including commit hash and build date
 func Full() string {
-	if !strings.HasSuffix(version, commitHash) {
-		version += " " + commitHash
-	}
-	osArch := runtime.GOOS + "/" + runtime.GOARCH
-	return fmt.Sprintf("%s %s BuildDate: %s", version, osArch, buildDate)
+	return current.String()
 }
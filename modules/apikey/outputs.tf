@@ -30,6 +30,7 @@ output "secret_jwt_template" {
 output "apikey" {
   description = "All attributes of the created API key resource"
   value       = local.outputs.module_enabled ? one(supabase_apikey.this.*) : null
+  sensitive   = true
 }
 
 # ----------------------------------------------------------------------------------------------------------------------
@@ -0,0 +1,14 @@
+output "ids" {
+  description = "IDs of the created apikeys by name"
+  value       = { for name, apikey in module.supabase_apikey : name => apikey.id }
+}
+
+output "project_id" {
+  description = "ID of the created Supabase project"
+  value       = module.supabase_project.id
+}
+
+output "module_enabled" {
+  description = "Whether the module was enabled"
+  value       = module.supabase_project.module_enabled
+}
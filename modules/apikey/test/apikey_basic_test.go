@@ -6,7 +6,10 @@ import (
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 
-	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/drift"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/ledger"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/preflight"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/state"
 	"github.com/hadenlabs/terraform-supabase/internal/testutil/supabase"
 )
 
@@ -14,44 +17,41 @@ func TestProjectBasicSuccess(t *testing.T) {
 	t.Parallel()
 
 	// Generate fake data for the test
-	project := supabase.NewProject()
+	stack := supabase.NewProjectWithAPIKeys(1)
+	apikey := stack.APIKeys[0]
 
-	organizationID := project.OrganizationID
-	databasePassword := project.DatabasePassword
-	name := project.Name
-	region := project.Region
-	apikeyName := faker.ApiKey().Name()
-	apikeyDescription := faker.ApiKey().Description()
+	vars := stack.Project.ToMap()
+	vars["apikey_name"] = apikey.Name
+	vars["apikey_description"] = apikey.Description
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := supabase.WithVarFile(t, supabase.WithProviderInstallation(&terraform.Options{
 		// The path to where your Terraform code is located
 		TerraformDir: "apikey-basic",
-		Upgrade:      true,
-		Vars: map[string]interface{}{
-			"database_password":       databasePassword,
-			"name":                    name,
-			"organization_id":         organizationID,
-			"region":                  region,
-			"legacy_api_keys_enabled": false,
-
-			"apikey_name":        apikeyName,
-			"apikey_description": apikeyDescription,
-		},
-	}
+		Vars:         vars,
+	}))
+
+	// Skip, rather than fail halfway, when the project is over budget or the plan limits
+	preflight.Require(t, terraformOptions)
 
 	// At the end of the test, run `terraform destroy` to clean up any resources that were created
-	defer terraform.Destroy(t, terraformOptions)
+	defer ledger.Destroy(t, terraformOptions)
 
 	// This will run `terraform init` and `terraform apply` and fail the test if there are any errors
-	terraform.InitAndApply(t, terraformOptions)
+	ledger.InitAndApply(t, terraformOptions)
+
+	// A second plan must be empty, otherwise an attribute never converges
+	drift.AssertNoDrift(t, terraformOptions)
 
 	// Verify outputs
-	outputApiKeyID := terraform.Output(t, terraformOptions, "id")
+	outputs := supabase.DecodeOutputs[supabase.APIKeyOutputs](t, terraformOptions)
 	outputProjectID := terraform.Output(t, terraformOptions, "project_id")
-	outputModuleEnabled := terraform.Output(t, terraformOptions, "module_enabled")
 
 	// Assertions
-	assert.NotEmpty(t, outputApiKeyID, "API Key ID should not be empty")
+	assert.NotEmpty(t, outputs.ID, "API Key ID should not be empty")
 	assert.NotEmpty(t, outputProjectID, "Project ID should not be empty")
-	assert.Equal(t, "true", outputModuleEnabled, "Module should be enabled")
+	assert.True(t, outputs.ModuleEnabled, "Module should be enabled")
+
+	// The resource in the state, not only the module outputs, carries the configured values
+	state.AssertResourceAttr(t, terraformOptions, "supabase_apikey.this[0]", "description", apikey.Description)
+	state.AssertResourceAttr(t, terraformOptions, "supabase_apikey.this[0]", "project_ref", outputProjectID)
 }
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/faker"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/drift"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/ledger"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/preflight"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/supabase"
+)
+
+func TestAPIKeyDriftRename(t *testing.T) {
+	t.Parallel()
+
+	stack := supabase.NewProjectWithAPIKeys(1)
+	apikey := stack.APIKeys[0]
+
+	vars := stack.Project.ToMap()
+	vars["apikey_name"] = apikey.Name
+	vars["apikey_description"] = apikey.Description
+
+	terraformOptions := supabase.WithVarFile(t, supabase.WithProviderInstallation(&terraform.Options{
+		TerraformDir: "apikey-basic",
+		Vars:         vars,
+	}))
+
+	// Skip, rather than fail halfway, when the project is over budget or the plan limits
+	preflight.Require(t, terraformOptions)
+
+	defer ledger.Destroy(t, terraformOptions)
+	ledger.InitAndApply(t, terraformOptions)
+
+	outputs := supabase.DecodeOutputs[supabase.APIKeyOutputs](t, terraformOptions)
+	projectID := terraform.Output(t, terraformOptions, "project_id")
+
+	// Rename the key behind terraform's back, the next apply must rename it back
+	client := management.NewFromConfig(config.Must())
+	changes := drift.AssertDetectsAndCorrects(t, terraformOptions,
+		drift.RenameAPIKey(client, projectID, outputs.ID, faker.ApiKey().Name()))
+
+	change, ok := changes.Resource("module.supabase_apikey.supabase_apikey.this[0]")
+	if assert.True(t, ok, changes.String()) {
+		assert.Equal(t, drift.ActionUpdate, change.Action, "a rename must not replace the key")
+	}
+}
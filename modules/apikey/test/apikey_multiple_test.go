@@ -0,0 +1,45 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/ledger"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/preflight"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/supabase"
+)
+
+func TestAPIKeyMultipleSuccess(t *testing.T) {
+	t.Parallel()
+
+	// Generate a project with two API keys
+	stack := supabase.NewProjectWithAPIKeys(2)
+
+	terraformOptions := supabase.WithVarFile(t, supabase.WithProviderInstallation(&terraform.Options{
+		// The path to where your Terraform code is located
+		TerraformDir: "apikey-multiple",
+		Vars:         stack.ToMap(),
+	}))
+
+	// Skip, rather than fail halfway, when the project is over budget or the plan limits
+	preflight.Require(t, terraformOptions)
+
+	// At the end of the test, run `terraform destroy` to clean up any resources that were created
+	defer ledger.Destroy(t, terraformOptions)
+
+	// This will run `terraform init` and `terraform apply` and fail the test if there are any errors
+	ledger.InitAndApply(t, terraformOptions)
+
+	// Verify outputs
+	ids := terraform.OutputMap(t, terraformOptions, "ids")
+	outputProjectID := terraform.Output(t, terraformOptions, "project_id")
+
+	// Assertions
+	assert.NotEmpty(t, outputProjectID, "Project ID should not be empty")
+	assert.Len(t, ids, len(stack.APIKeys), "Every API key should be created")
+	for _, apikey := range stack.APIKeys {
+		assert.NotEmpty(t, ids[apikey.Name], "API Key ID should not be empty")
+	}
+}
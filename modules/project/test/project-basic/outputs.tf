@@ -1,4 +1,4 @@
-output "project_id" {
+output "id" {
   description = "ID of the created Supabase project"
   value       = module.supabase_project.id
 }
@@ -6,6 +6,10 @@ import (
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/drift"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/ledger"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/preflight"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/state"
 	"github.com/hadenlabs/terraform-supabase/internal/testutil/supabase"
 )
 
@@ -20,10 +24,9 @@ func TestProjectBasicSuccess(t *testing.T) {
 	name := project.Name
 	region := project.Region
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := supabase.WithVarFile(t, supabase.WithProviderInstallation(&terraform.Options{
 		// The path to where your Terraform code is located
 		TerraformDir: "project-basic",
-		Upgrade:      true,
 		Vars: map[string]interface{}{
 			"database_password":       databasePassword,
 			"name":                    name,
@@ -32,19 +35,28 @@ func TestProjectBasicSuccess(t *testing.T) {
 			"legacy_api_keys_enabled": false,
 			"module_enabled":          true,
 		},
-	}
+	}))
+
+	// Skip, rather than fail halfway, when the project is over budget or the plan limits
+	preflight.Require(t, terraformOptions)
 
 	// At the end of the test, run `terraform destroy` to clean up any resources that were created
-	defer terraform.Destroy(t, terraformOptions)
+	defer ledger.Destroy(t, terraformOptions)
 
 	// This will run `terraform init` and `terraform apply` and fail the test if there are any errors
-	terraform.InitAndApply(t, terraformOptions)
+	ledger.InitAndApply(t, terraformOptions)
+
+	// A second plan must be empty, otherwise an attribute never converges
+	drift.AssertNoDrift(t, terraformOptions)
 
 	// Verify outputs
-	outputProjectID := terraform.Output(t, terraformOptions, "project_id")
-	outputModuleEnabled := terraform.Output(t, terraformOptions, "module_enabled")
+	outputs := supabase.DecodeOutputs[supabase.ProjectOutputs](t, terraformOptions)
 
 	// Assertions
-	assert.NotEmpty(t, outputProjectID, "Project ID should not be empty")
-	assert.Equal(t, "true", outputModuleEnabled, "Module should be enabled")
+	assert.NotEmpty(t, outputs.ID, "Project ID should not be empty")
+	assert.True(t, outputs.ModuleEnabled, "Module should be enabled")
+
+	// Attributes the module does not output are read from the state
+	state.AssertResourceAttr(t, terraformOptions, "supabase_project.this[0]", "region", region)
+	state.AssertResourceAttr(t, terraformOptions, "supabase_project.this[0]", "legacy_api_keys_enabled", false)
 }
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/app/external/management"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/imports"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/ledger"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/preflight"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/supabase"
+)
+
+func TestProjectImport(t *testing.T) {
+	t.Parallel()
+
+	for _, method := range []imports.Method{imports.MethodBlock, imports.MethodCommand} {
+		method := method
+		t.Run(string(method), func(t *testing.T) {
+			t.Parallel()
+
+			project := supabase.NewProject()
+
+			// The import block is written into the root module, so every run works on its own copy
+			module, err := files.CopyTerraformFolderToDest("..", t.TempDir(), "project-import")
+			require.NoError(t, err)
+			terraformOptions := supabase.WithVarFile(t, supabase.WithProviderInstallation(&terraform.Options{
+				TerraformDir: filepath.Join(module, "test", "project-basic"),
+				Vars: map[string]interface{}{
+					"database_password":       project.DatabasePassword,
+					"name":                    project.Name,
+					"organization_id":         project.OrganizationID,
+					"region":                  project.Region,
+					"instance_size":           project.InstanceSize,
+					"legacy_api_keys_enabled": false,
+					"module_enabled":          true,
+				},
+			}))
+			preflight.Require(t, terraformOptions)
+
+			// Create the project outside of terraform
+			client := management.NewFromConfig(config.Must())
+			created := imports.CreateProject(t, client, project)
+			ledger.Default().RecordResources(t, terraformOptions, ledger.ActionCreate, ledger.Resource{
+				Address:    imports.ProjectAddress,
+				Type:       ledger.TypeProject,
+				ID:         created.ID,
+				ProjectRef: created.ID,
+			})
+
+			defer ledger.Destroy(t, terraformOptions)
+
+			// Adopt the project and fail on every attribute the configuration would change
+			imports.AssertClean(t, terraformOptions, method, imports.ProjectAddress, created.ID)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/matrix"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/supabase"
+)
+
+func TestProjectCompatibilityMatrix(t *testing.T) {
+	cfg, err := matrix.FromConfig(config.Must())
+	require.NoError(t, err)
+	if len(cfg.TerraformVersions) == 0 && len(cfg.ProviderVersions) == 0 {
+		t.Skip("no matrix configured, set TF_MATRIX_TERRAFORM_VERSIONS or TF_MATRIX_PROVIDERS")
+	}
+
+	// Only plans, no resources are created
+	terraformOptions := supabase.WithVarFile(t, supabase.DefaultForModule("project-basic"))
+	report := matrix.Run(t, cfg, terraformOptions, matrix.Plan)
+	require.NotEmpty(t, report.Compatible(), report.String())
+}
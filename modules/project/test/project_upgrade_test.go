@@ -0,0 +1,34 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+
+	"github.com/hadenlabs/terraform-supabase/config"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/preflight"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/supabase"
+	"github.com/hadenlabs/terraform-supabase/internal/testutil/upgrade"
+)
+
+func TestProjectUpgrade(t *testing.T) {
+	t.Parallel()
+
+	project := supabase.NewProject()
+
+	terraformOptions := supabase.WithVarFile(t, supabase.WithProviderInstallation(&terraform.Options{
+		TerraformDir: "project-basic",
+		Vars: map[string]interface{}{
+			"database_password":       project.DatabasePassword,
+			"name":                    project.Name,
+			"organization_id":         project.OrganizationID,
+			"region":                  project.Region,
+			"legacy_api_keys_enabled": false,
+			"module_enabled":          true,
+		},
+	}))
+	preflight.Require(t, terraformOptions)
+
+	// Applies project-basic as released, then plans it against the working tree
+	upgrade.Run(t, terraformOptions, config.Must().Terraform.UpgradeFrom)
+}